@@ -0,0 +1,201 @@
+package esrp
+
+import (
+	"sync"
+
+	e "github.com/nsheremet/esrp/engine"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// clientState type: client handshake step
+type clientState int
+
+const (
+	clientNew clientState = iota
+	clientStarted
+	clientChallenged
+	clientVerified
+	clientFailed
+)
+
+// Client struct: client side of SRP handshake
+//
+// Wires engine calculations in the right order:
+//
+//   client := esrp.NewClient(engine.Session(), username, password)
+//   aa, _ := client.StartAuthentication()        // send I, A to the server
+//   mm, err := client.ProcessChallenge(salt, bb) // send M to the server
+//   err = client.VerifyServerProof(mm2)
+//   kk, _ := client.SessionKey()
+//
+// Any failure makes the client unusable, a new one is required to retry.
+// Client is not safe for concurrent use.
+type Client struct {
+	engine   e.Session
+	username string
+	password string
+	state    clientState
+	a        v.Value
+	aa       v.Value
+	kk       v.Value
+	mm       v.Value
+	ss       v.Value
+}
+
+// clientPool {sync.Pool} pool of reusable clients, see AcquireClient
+var clientPool = sync.Pool{New: func() interface{} { return new(Client) }}
+
+// NewClient function Constructor
+//
+// Params:
+// - engine   {engine.Session} engine shared with the server
+// - username {string} plain-text username in UTF8 string (I)
+// - password {string} plain-text password in UTF8 string (p)
+//
+// Response:
+// - {*Client}
+func NewClient(engine e.Session, username, password string) *Client {
+	return &Client{
+		engine:   engine,
+		username: username,
+		password: password,
+	}
+}
+
+// AcquireClient function: client from the pool
+//
+// Cuts allocation churn for callers doing many handshakes. Client should be
+// returned to the pool with Reset once handshake is over.
+//
+// Params:
+// - engine   {engine.Session} engine shared with the server
+// - username {string} plain-text username in UTF8 string (I)
+// - password {string} plain-text password in UTF8 string (p)
+//
+// Response:
+// - {*Client}
+func AcquireClient(engine e.Session, username, password string) *Client {
+	c := clientPool.Get().(*Client)
+	c.engine, c.username, c.password = engine, username, password
+
+	return c
+}
+
+// Reset function: wipe secrets and return client to the pool
+//
+// Client must not be used afterwards, values returned earlier
+// (including session key) stay valid.
+func (c *Client) Reset() {
+	c.a.Wipe()
+	c.kk.Wipe()
+	c.ss.Wipe()
+	*c = Client{}
+	clientPool.Put(c)
+}
+
+// StartAuthentication function: generate client ephemeral values
+//
+// Response:
+// - {esrp.Value} public client ephemeral value (A)
+// - {error}
+func (c *Client) StartAuthentication() (v.Value, error) {
+	if c.state != clientNew {
+		return v.Value{}, c.fail(ErrInvalidState)
+	}
+
+	c.a = c.engine.Crypto().Random(EphemeralLength)
+	c.aa = c.engine.CalcA(c.a)
+	c.state = clientStarted
+
+	return c.aa, nil
+}
+
+// ProcessChallenge function: calculate session key and client proof
+//
+// Aborts if B mod N == 0 or u == 0. Password is dropped afterwards,
+// private key (x) and secret ephemeral value (a) are wiped.
+//
+// Params:
+// - salt {esrp.Value} user's salt (s)
+// - bb   {esrp.Value} public server ephemeral value (B)
+//
+// Response:
+// - {esrp.Value} validation message (M)
+// - {error}
+func (c *Client) ProcessChallenge(salt, bb v.Value) (v.Value, error) {
+	if c.state != clientStarted {
+		return v.Value{}, c.fail(ErrInvalidState)
+	}
+
+	if !c.engine.IsValidPublic(bb) {
+		return v.Value{}, c.fail(ErrInvalidChallenge)
+	}
+
+	u := c.engine.CalcU(c.aa, bb)
+
+	if u.Int().Sign() == 0 {
+		return v.Value{}, c.fail(ErrInvalidChallenge)
+	}
+
+	x := c.engine.CalcX(c.password, salt, c.username)
+	c.password = ""
+	c.ss = c.engine.CalcClientS(bb, c.a, x, u)
+	x.Wipe()
+	c.a.Wipe()
+	c.a = v.Value{}
+	c.kk = c.engine.CalcK(c.ss)
+	c.mm = c.engine.CalcM(c.kk, c.aa, bb, c.ss, salt, c.username)
+	c.state = clientChallenged
+
+	return c.mm, nil
+}
+
+// VerifyServerProof function: check server response validation message
+//
+// Params:
+// - mm2 {esrp.Value} response validation message (M2)
+//
+// Response:
+// - {error} ErrInvalidProof if server doesn't know the verifier
+func (c *Client) VerifyServerProof(mm2 v.Value) error {
+	if c.state != clientChallenged {
+		return c.fail(ErrInvalidState)
+	}
+
+	expected := c.engine.CalcM2(c.kk, c.aa, c.mm, c.ss)
+
+	if !c.engine.Crypto().SecureCompare(expected, mm2) {
+		return c.fail(ErrInvalidProof)
+	}
+
+	c.ss.Wipe()
+	c.ss = v.Value{}
+	c.state = clientVerified
+
+	return nil
+}
+
+// SessionKey function: private session key (K) of verified handshake
+//
+// Response:
+// - {esrp.Value} private session key (K)
+// - {error} ErrInvalidState until server proof is verified
+func (c *Client) SessionKey() (v.Value, error) {
+	if c.state != clientVerified {
+		return v.Value{}, ErrInvalidState
+	}
+
+	return v.New(c.kk.Bytes()), nil
+}
+
+// fail function: wipe secrets and make client unusable
+func (c *Client) fail(err error) error {
+	c.state = clientFailed
+	c.password = ""
+	c.a.Wipe()
+	c.kk.Wipe()
+	c.ss.Wipe()
+	c.a, c.kk, c.ss = v.Value{}, v.Value{}, v.Value{}
+
+	return err
+}
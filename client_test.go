@@ -0,0 +1,147 @@
+package esrp_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	"github.com/nsheremet/esrp"
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+var handshakeEngine = e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)}.Session()
+
+// server struct: server answer calculated with engine directly
+type server struct {
+	bb, kk, mm, ss value.Value
+}
+
+func newServer(aa, salt value.Value, password string) server {
+	return newServerWith(handshakeEngine, aa, salt, password)
+}
+
+func newServerWith(engine e.Session, aa, salt value.Value, password string) server {
+	val := engine.CalcV(engine.CalcX(password, salt, "alice"))
+	b := engine.Crypto().Random(32)
+	bb := engine.CalcB(b, val)
+	ss := engine.CalcServerS(aa, b, val, engine.CalcU(aa, bb))
+	kk := engine.CalcK(ss)
+
+	return server{bb: bb, kk: kk, mm: engine.CalcM(kk, aa, bb, ss, salt, "alice"), ss: ss}
+}
+
+func (s server) mm2(aa, mm value.Value) value.Value {
+	return handshakeEngine.CalcM2(s.kk, aa, mm, s.ss)
+}
+
+// clientHandshake function: M of client and server for engine
+func clientHandshake(client, srv e.Session) (string, string) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	instance := esrp.NewClient(client, "alice", "password123")
+	aa, _ := instance.StartAuthentication()
+	answer := newServerWith(srv, aa, salt, "password123")
+	mm, _ := instance.ProcessChallenge(salt, answer.bb)
+
+	return mm.Hex(), answer.mm.Hex()
+}
+
+func TestClientHandshake(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	client := esrp.NewClient(handshakeEngine, "alice", "password123")
+
+	aa, err := client.StartAuthentication()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := newServer(aa, salt, "password123")
+	mm, err := client.ProcessChallenge(salt, srv.bb)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mm.Hex() != srv.mm.Hex() {
+		t.Error("M should be equal")
+	}
+
+	if _, err := client.SessionKey(); err != esrp.ErrInvalidState {
+		t.Error("session key should be unavailable before server proof")
+	}
+
+	if err := client.VerifyServerProof(srv.mm2(aa, mm)); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := client.SessionKey()
+
+	if err != nil || key.Hex() != srv.kk.Hex() {
+		t.Error("session key should be equal")
+	}
+}
+
+func TestClientWrongPassword(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	client := esrp.NewClient(handshakeEngine, "alice", "password124")
+	aa, _ := client.StartAuthentication()
+	srv := newServer(aa, salt, "password123")
+	mm, _ := client.ProcessChallenge(salt, srv.bb)
+
+	if mm.Hex() == srv.mm.Hex() {
+		t.Error("M should differ")
+	}
+
+	if client.VerifyServerProof(srv.mm2(aa, srv.mm)) != esrp.ErrInvalidProof {
+		t.Error("server proof should be rejected")
+	}
+
+	if _, err := client.SessionKey(); err != esrp.ErrInvalidState {
+		t.Error("session key should be unavailable")
+	}
+}
+
+func TestClientInvalidChallenge(t *testing.T) {
+	for _, bb := range []value.Value{value.New("00"), grp.N} {
+		client := esrp.NewClient(handshakeEngine, "alice", "password123")
+		client.StartAuthentication()
+
+		if _, err := client.ProcessChallenge(value.New("0451"), bb); err != esrp.ErrInvalidChallenge {
+			t.Error("B mod N == 0 should be rejected")
+		}
+	}
+}
+
+func TestClientOrder(t *testing.T) {
+	client := esrp.NewClient(handshakeEngine, "alice", "password123")
+
+	if _, err := client.ProcessChallenge(value.New("0451"), value.New("02")); err != esrp.ErrInvalidState {
+		t.Error("challenge before start should be rejected")
+	}
+
+	if _, err := client.StartAuthentication(); err != esrp.ErrInvalidState {
+		t.Error("failed client should be unusable")
+	}
+}
+
+func TestClientEngines(t *testing.T) {
+	standard := e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)}
+	accountKey := e.NewAccountKey(standard, value.New("a3f1c0b2d4e5f60718293a4b5c6d7e8f"), "alice")
+
+	engines := map[string]e.Session{
+		"RFC5054":    e.NewRFC5054(grp),
+		"SrpRb":      e.NewSrpRb(grp),
+		"AccountKey": accountKey.Session(),
+	}
+
+	for name, engine := range engines {
+		if mm, expected := clientHandshake(engine, engine); mm != expected {
+			t.Errorf("M should be equal with %s engine", name)
+		}
+	}
+
+	if mm, expected := clientHandshake(accountKey.Session(), standard.Session()); mm == expected {
+		t.Error("x should be mixed with account key")
+	}
+}
@@ -0,0 +1,30 @@
+// Command esrp-vectors exports canonical test suite of built-in engine profiles
+//
+// Usage:
+//   esrp-vectors > vectors.json
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/nsheremet/esrp/vectors"
+)
+
+func main() {
+	suite, err := vectors.Generate(vectors.DefaultInputs)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out, err := vectors.JSON(suite)
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err = os.Stdout.Write(append(out, '\n')); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,169 @@
+// Package credential portable credential blob for syncing SRP clients
+//
+// Password-manager-style clients keep SRP credentials (username, profile,
+// salt and optionally private key x) and sync them across devices:
+//
+//   blob := credential.Blob{Username: "alice", Profile: "standard-sha256", Salt: salt, X: x}
+//   data, err := credential.Encrypt(blob, syncKey)
+//   blob, err = credential.Decrypt(data, syncKey)
+//
+// Blob is encoded as versioned JSON with hex encoded values. Private key (x)
+// is equivalent to the password for SRP, so it's never written in plain:
+// Encode refuses blobs with x, Encrypt wraps the whole blob with filecrypt.
+package credential
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/nsheremet/esrp/filecrypt"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Version {int} current blob format version
+const Version = 1
+
+// ErrInvalidBlob is returned when blob can't be decoded
+var ErrInvalidBlob = errors.New("credential: invalid blob")
+
+// ErrUnsupportedVersion is returned when blob is produced by a newer format version
+var ErrUnsupportedVersion = errors.New("credential: unsupported blob version")
+
+// ErrPlaintextKey is returned when blob with private key (x) is encoded without encryption
+var ErrPlaintextKey = errors.New("credential: private key must be encrypted")
+
+// Blob struct: credential blob
+//
+// Provides:
+// Username - plain-text username in UTF8 string (I)
+// Profile  - engine profile name
+// Salt     - salt (s)
+// X        - private key (x), optional, lets the client skip KDF on every login
+type Blob struct {
+	Username string
+	Profile  string
+	Salt     v.Value
+	X        v.Value
+}
+
+// wire struct: JSON representation of blob
+type wire struct {
+	Version  int    `json:"version"`
+	Username string `json:"username"`
+	Profile  string `json:"profile"`
+	Salt     string `json:"salt"`
+	X        string `json:"x,omitempty"`
+}
+
+// Encode function: blob without private key as JSON
+//
+// Params:
+// - blob {Blob}
+//
+// Response:
+// - {[]byte}
+// - {error} ErrPlaintextKey if private key (x) is set
+func Encode(blob Blob) ([]byte, error) {
+	if hasKey(blob) {
+		return nil, ErrPlaintextKey
+	}
+
+	return encode(blob)
+}
+
+// Decode function: blob from JSON
+//
+// Params:
+// - data {[]byte}
+//
+// Response:
+// - {Blob}
+// - {error} ErrInvalidBlob if any field is missing or malformed,
+//           ErrUnsupportedVersion for newer format versions
+func Decode(data []byte) (Blob, error) {
+	var w wire
+
+	if err := json.Unmarshal(data, &w); err != nil {
+		return Blob{}, ErrInvalidBlob
+	}
+
+	if w.Version > Version {
+		return Blob{}, ErrUnsupportedVersion
+	}
+
+	if w.Version <= 0 || w.Username == "" || w.Profile == "" || !v.IsHex(w.Salt) || (w.X != "" && !v.IsHex(w.X)) {
+		return Blob{}, ErrInvalidBlob
+	}
+
+	blob := Blob{Username: w.Username, Profile: w.Profile, Salt: v.New(w.Salt)}
+
+	if w.X != "" {
+		blob.X = v.New(w.X)
+	}
+
+	return blob, nil
+}
+
+// Encrypt function: encoded blob encrypted with sync key
+//
+// Params:
+// - blob   {Blob}
+// - secret {esrp.Value} sync key: stable secret shared by the devices
+//                       independently of the blob, e.g. user-held recovery
+//                       or account key. Neither x, which the blob carries,
+//                       nor K, which changes every session, can be used
+//
+// Response:
+// - {[]byte}
+// - {error}
+func Encrypt(blob Blob, secret v.Value) ([]byte, error) {
+	data, err := encode(blob)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+
+	if err = filecrypt.Encrypt(&out, bytes.NewReader(data), secret); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// Decrypt function: blob from data produced by Encrypt
+//
+// Params:
+// - data   {[]byte}
+// - secret {esrp.Value} the same sync key as used for Encrypt
+//
+// Response:
+// - {Blob}
+// - {error} filecrypt errors if data is corrupted or key is wrong
+func Decrypt(data []byte, secret v.Value) (Blob, error) {
+	var out bytes.Buffer
+
+	if err := filecrypt.Decrypt(&out, bytes.NewReader(data), secret); err != nil {
+		return Blob{}, err
+	}
+
+	return Decode(out.Bytes())
+}
+
+// encode function: blob as JSON, including private key
+func encode(blob Blob) ([]byte, error) {
+	w := wire{Version: Version, Username: blob.Username, Profile: blob.Profile, Salt: blob.Salt.Hex()}
+
+	if hasKey(blob) {
+		w.X = blob.X.Hex()
+	}
+
+	return json.Marshal(w)
+}
+
+// hasKey function: true if blob contains private key (x)
+func hasKey(blob Blob) bool {
+	return len(blob.X.Bytes()) > 0
+}
@@ -0,0 +1,89 @@
+package credential_test
+
+import (
+	"testing"
+
+	"github.com/nsheremet/esrp/credential"
+	"github.com/nsheremet/esrp/filecrypt"
+	"github.com/nsheremet/esrp/value"
+)
+
+var syncKey = value.New("0c486f95b2986a6f8a2b1f9368d7472dc615f1b5")
+
+var blob = credential.Blob{
+	Username: "alice",
+	Profile:  "standard-sha256",
+	Salt:     value.New("beb25379d1a8581eb5a727673a2441ee"),
+}
+
+func TestEncodeDecode(t *testing.T) {
+	data, err := credential.Encode(blob)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"version":1,"username":"alice","profile":"standard-sha256","salt":"beb25379d1a8581eb5a727673a2441ee"}` {
+		t.Errorf("blob should be encoded: %s", data)
+	}
+
+	decoded, err := credential.Decode(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Username != "alice" || decoded.Profile != "standard-sha256" || decoded.Salt.Hex() != blob.Salt.Hex() || decoded.X.Bytes() != nil {
+		t.Error("decoded blob should be equal")
+	}
+}
+
+func TestEncodePlaintextKey(t *testing.T) {
+	withKey := blob
+	withKey.X = value.New("94b7555aabe9127cc58ccf4993db6cf84d16c124")
+
+	if _, err := credential.Encode(withKey); err != credential.ErrPlaintextKey {
+		t.Error("private key should not be encoded in plain")
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	cases := map[string]error{
+		`{`: credential.ErrInvalidBlob,
+		`{"version":1,"username":"alice","profile":"p"}`:                      credential.ErrInvalidBlob,
+		`{"username":"alice","profile":"p","salt":"00"}`:                      credential.ErrInvalidBlob,
+		`{"version":1,"username":"alice","profile":"p","salt":"00","x":"zz"}`: credential.ErrInvalidBlob,
+		`{"version":2,"username":"alice","profile":"p","salt":"00"}`:          credential.ErrUnsupportedVersion,
+	}
+
+	for data, expected := range cases {
+		if _, err := credential.Decode([]byte(data)); err != expected {
+			t.Errorf("%s should be rejected with %v, got %v", data, expected, err)
+		}
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	withKey := blob
+	withKey.X = value.New("94b7555aabe9127cc58ccf4993db6cf84d16c124")
+
+	data, err := credential.Encrypt(withKey, syncKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decrypted, err := credential.Decrypt(data, syncKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decrypted.Username != "alice" || decrypted.X.Hex() != withKey.X.Hex() {
+		t.Error("decrypted blob should be equal")
+	}
+
+	if _, err = credential.Decrypt(data, value.New("01")); err != filecrypt.ErrAuthentication {
+		t.Error("wrong key should be rejected")
+	}
+}
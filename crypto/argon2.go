@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"crypto"
+
+	v "github.com/nsheremet/esrp/value"
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2Params struct: argon2id cost parameters
+//
+// Provides:
+// Time    - number of passes over the memory
+// Memory  - memory size in KiB
+// Threads - degree of parallelism
+type Argon2Params struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+// DefaultArgon2Params {Argon2Params} second recommended option of RFC9106:
+// t=3, m=64MiB, p=4
+var DefaultArgon2Params = Argon2Params{Time: 3, Memory: 64 * 1024, Threads: 4}
+
+// Argon2 struct: Standard crypto engine with argon2id key derivation
+//
+// PasswordHash uses memory-hard argon2id instead of PBKDF2, so x derivation
+// is resistant to GPU and ASIC cracking. Output length is equal to hash size.
+// H, KeyedHash, Random and SecureCompare are the same as Standard provides.
+type Argon2 struct {
+	Standard
+	params Argon2Params
+}
+
+// NewArgon2 public function:
+//
+// Params:
+// - hash   {crypto.Hash} Hash type, example: SHA256, SHA512
+// - params {Argon2Params} argon2id cost parameters
+//
+// Response:
+// - {Argon2}
+func NewArgon2(hash crypto.Hash, params Argon2Params) Argon2 {
+	return Argon2{Standard: NewStandard(hash), params: params}
+}
+
+// PasswordHash public function: argon2id key derivation function
+//
+// Params:
+// - salt {esrp.Value} random generated salt
+// - password {string} plain-text password
+//
+// Response:
+// - esrp.Value
+func (a Argon2) PasswordHash(salt v.Value, password string) v.Value {
+	return v.New(argon2.IDKey(
+		[]byte(password),
+		salt.Bytes(),
+		a.params.Time,
+		a.params.Memory,
+		a.params.Threads,
+		uint32(a.hasher.Size()),
+	))
+}
+
+// KDFParams public function: parameters used by PasswordHash
+//
+// Response:
+// - {KDFParams}
+func (a Argon2) KDFParams() KDFParams {
+	return KDFParams{
+		Name:       "argon2id",
+		Hash:       a.hasher.String(),
+		Iterations: int(a.params.Time),
+		Memory:     a.params.Memory,
+		Threads:    a.params.Threads,
+	}
+}
+
+// Argon2Params public function: argon2id cost parameters
+//
+// Response:
+// - {Argon2Params}
+func (a Argon2) Argon2Params() Argon2Params {
+	return a.params
+}
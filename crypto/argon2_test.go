@@ -0,0 +1,37 @@
+package crypto
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/nsheremet/esrp/value"
+)
+
+var argon2Params = Argon2Params{Time: 1, Memory: 64, Threads: 1}
+
+func TestArgon2PasswordHash(t *testing.T) {
+	instance := NewArgon2(crypto.SHA256, argon2Params)
+	subj := instance.PasswordHash(value.New([]byte("somesalt")), "password")
+
+	if subj.Hex() != "729c7a54441bc13559bdca71348c4e554599e719c08a952601ed5c83618c1bbd" {
+		t.Error("hash should be equal")
+	}
+
+	if subj.Hex() == NewArgon2(crypto.SHA256, Argon2Params{Time: 2, Memory: 64, Threads: 1}).PasswordHash(value.New([]byte("somesalt")), "password").Hex() {
+		t.Error("hash should depend on cost parameters")
+	}
+}
+
+func TestArgon2KDFParams(t *testing.T) {
+	expected := KDFParams{Name: "argon2id", Hash: "SHA-256", Iterations: 3, Memory: 64 * 1024, Threads: 4}
+
+	if NewArgon2(crypto.SHA256, DefaultArgon2Params).KDFParams() != expected {
+		t.Error("params should be equal")
+	}
+}
+
+func TestArgon2Conformance(t *testing.T) {
+	if err := Conformance(NewArgon2(crypto.SHA256, argon2Params)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"crypto"
+	"hash"
+	"log"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+)
+
+// blake2Mac function: keyed BLAKE2 (RFC7693)
+//
+// BLAKE2s is used for BLAKE2s_256 hash, BLAKE2b with output length
+// equal to hash size otherwise. Keys longer than BLAKE2 allows
+// (64 bytes for BLAKE2b, 32 for BLAKE2s) are hashed first, as HMAC does.
+//
+//   MAC = BLAKE2(K, X)
+//
+// Params:
+// - hasher {crypto.Hash} selected hash, defines BLAKE2 variant and output length
+// - key    {[]byte} secret key (K)
+// - msg    {[]byte} message (X)
+//
+// Response:
+// - {[]byte}
+func blake2Mac(hasher crypto.Hash, key, msg []byte) []byte {
+	var mac hash.Hash
+	var err error
+
+	if hasher == crypto.BLAKE2s_256 {
+		if len(key) > blake2s.Size {
+			sum := blake2s.Sum256(key)
+			key = sum[:]
+		}
+
+		mac, err = blake2s.New256(key)
+	} else {
+		if len(key) > blake2b.Size {
+			sum := blake2b.Sum512(key)
+			key = sum[:]
+		}
+
+		mac, err = blake2b.New(hasher.Size(), key)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mac.Write(msg)
+
+	return mac.Sum(nil)
+}
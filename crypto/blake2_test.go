@@ -0,0 +1,44 @@
+package crypto
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestStandardHWithBLAKE2(t *testing.T) {
+	cases := map[crypto.Hash]string{
+		crypto.BLAKE2b_256: "db37202f77f5c6c7c6dd07f893547753d7f07dc649e97477eaca178366cc0125",
+		crypto.BLAKE2b_512: "924bb7d1885981f00d721ace8e92406ff2d411d66f366c2273141f78fb4fca7a1f44ed8fa53e7433d4ea0b4d61cc24a2c8c388e5010a38dec869015c392d71bd",
+		crypto.BLAKE2s_256: "e1679a82537e078b934bd021d41d8a7de429e0e2badffc9092a384fd29b11758",
+	}
+
+	for hash, expected := range cases {
+		if NewStandard(hash).H(val).Hex() != expected {
+			t.Errorf("hash should be equal for %s", hash)
+		}
+	}
+}
+
+func TestStandardKeyedHashWithBlake2Keyed(t *testing.T) {
+	cases := map[crypto.Hash]string{
+		crypto.BLAKE2b_256: "945ed28652c326107875a33a899c876f0569a903f58337c9441ba729404bee54",
+		crypto.BLAKE2s_256: "15e2f503f44307dac2a1a2dfeea97371614086b4a5ff5a2ae4bf72c34307a339",
+	}
+
+	for hash, expected := range cases {
+		if NewStandardWithParams(hash, StandardParams{Mac: Blake2Keyed}).KeyedHash(key, msg).Hex() != expected {
+			t.Errorf("mac should be equal for %s", hash)
+		}
+	}
+}
+
+func TestStandardKeyedHashWithBlake2KeyedLongKey(t *testing.T) {
+	long := value.New(key.Hex() + key.Hex() + key.Hex())
+	subj := NewStandardWithParams(crypto.BLAKE2b_512, StandardParams{Mac: Blake2Keyed}).KeyedHash(long, msg)
+
+	if subj.Hex() != "0bbd6231a1677a67cbec88f1354f179981771d9ac012c6d3c5de91e487d242e7133218f13234ff942bce94c2cc6e3ea606f48d161af2e878ee6cfe3a73af9005" {
+		t.Error("long key should be hashed first")
+	}
+}
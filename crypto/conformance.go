@@ -0,0 +1,83 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Conformance function: check custom Crypto implementation before use
+//
+// Checks that:
+// - H, PasswordHash and KeyedHash are deterministic, depend on every input
+//   and H and KeyedHash have fixed output length
+// - SecureCompare accepts equal values and rejects different ones,
+//   including values of different length (timing is not measured)
+// - Random returns values of requested length, which don't repeat
+//
+// Params:
+// - impl {Crypto} crypto implementation
+//
+// Response:
+// - {error} first violated property, nil if implementation conforms
+func Conformance(impl Crypto) error {
+	short := v.New([]byte("esrp"))
+	long := v.New(bytes.Repeat([]byte("conformance"), 16))
+
+	hash := impl.H(short)
+
+	if len(hash.Bytes()) == 0 || !same(hash, impl.H(short)) {
+		return errors.New("crypto: conformance: H should be deterministic")
+	}
+
+	if same(hash, impl.H(long)) || len(impl.H(long).Bytes()) != len(hash.Bytes()) {
+		return errors.New("crypto: conformance: H should depend on input and have fixed length")
+	}
+
+	salt := v.New([]byte("salt"))
+	key := impl.PasswordHash(salt, "password")
+
+	if len(key.Bytes()) == 0 || !same(key, impl.PasswordHash(salt, "password")) {
+		return errors.New("crypto: conformance: PasswordHash should be deterministic")
+	}
+
+	if same(key, impl.PasswordHash(salt, "passwore")) || same(key, impl.PasswordHash(v.New([]byte("salu")), "password")) {
+		return errors.New("crypto: conformance: PasswordHash should depend on salt and password")
+	}
+
+	mac := impl.KeyedHash(short, long)
+
+	if len(mac.Bytes()) == 0 || !same(mac, impl.KeyedHash(short, long)) {
+		return errors.New("crypto: conformance: KeyedHash should be deterministic")
+	}
+
+	if same(mac, impl.KeyedHash(long, long)) || same(mac, impl.KeyedHash(short, short)) || len(impl.KeyedHash(long, short).Bytes()) != len(mac.Bytes()) {
+		return errors.New("crypto: conformance: KeyedHash should depend on key and message and have fixed length")
+	}
+
+	if !impl.SecureCompare(hash, impl.H(short)) {
+		return errors.New("crypto: conformance: SecureCompare should accept equal values")
+	}
+
+	if impl.SecureCompare(short, v.New([]byte("esrq"))) || impl.SecureCompare(short, long) {
+		return errors.New("crypto: conformance: SecureCompare should reject different values")
+	}
+
+	for _, length := range []int{1, 16, 32, 64} {
+		if len(impl.Random(length).Bytes()) != length {
+			return errors.New("crypto: conformance: Random should return value of requested length")
+		}
+	}
+
+	if same(impl.Random(32), impl.Random(32)) {
+		return errors.New("crypto: conformance: Random should not repeat values")
+	}
+
+	return nil
+}
+
+// same function: values are equal by bytes
+func same(a, b v.Value) bool {
+	return bytes.Equal(a.Bytes(), b.Bytes())
+}
@@ -0,0 +1,31 @@
+package crypto
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/nsheremet/esrp/value"
+)
+
+// fixedRandom struct: crypto with broken random generator
+type fixedRandom struct {
+	Standard
+}
+
+func (f fixedRandom) Random(bytesLength int) value.Value {
+	return value.New(make([]byte, bytesLength))
+}
+
+func TestConformanceStandard(t *testing.T) {
+	for _, instance := range []Crypto{NewStandard(crypto.SHA1), NewStandardWithParams(crypto.SHA256, StandardParams{LegacyKdf: true, Mac: LegacyConcat}), NewStandardWithParams(crypto.SHA512, StandardParams{Mac: Kmac256}), NewStandardWithParams(crypto.BLAKE2b_256, StandardParams{Mac: Blake2Keyed})} {
+		if err := Conformance(instance); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestConformanceFixedRandom(t *testing.T) {
+	if Conformance(fixedRandom{NewStandard(crypto.SHA256)}) == nil {
+		t.Error("repeating random should not conform")
+	}
+}
@@ -1,7 +1,26 @@
 // Package crypto with Crypto interface
+//
+// OpenSSL engine requires cgo, so it is excluded from TinyGo and WASI builds
+// and from reduced-feature builds with "esrp_tiny" build tag:
+//
+//   go build -tags esrp_tiny ./...
+//   GOOS=wasip1 GOARCH=wasm go build ./...
+//
+// Runtimes without working crypto/rand may inject entropy explicitly
+// (see NewEntropy).
+//
+// Standard engine relies on Go stdlib only and is always available.
+// Argon2 engine is Standard with argon2id password hashing (see NewArgon2).
+//
+// Legacy MD5 and RIPEMD-160 hashes (see NewInsecureLegacy) are compiled only
+// with "insecure_legacy" build tag, constructors refuse them otherwise:
+//
+//   go test -tags insecure_legacy ./...
 package crypto
 
 import (
+	"crypto"
+
 	v "github.com/nsheremet/esrp/value"
 )
 
@@ -73,3 +92,59 @@ type Crypto interface {
 	// - {bool} true if strings are equal
 	SecureCompare(a v.Value, b v.Value) bool
 }
+
+// Hasher interface: crypto engines describing their hash (see Standard)
+type Hasher interface {
+	Hash() crypto.Hash
+}
+
+// Describer interface: crypto engines describing their KDF and mac (see Standard)
+type Describer interface {
+	KDFParams() KDFParams
+	MacMode() MacMode
+}
+
+// HashOf function: hash of crypto engine, used by wrappers to forward Hasher
+//
+// Params:
+// - impl {Crypto}
+//
+// Response:
+// - {crypto.Hash} zero if engine doesn't describe its hash
+func HashOf(impl Crypto) crypto.Hash {
+	if d, ok := impl.(Hasher); ok {
+		return d.Hash()
+	}
+
+	return 0
+}
+
+// KDFParamsOf function: KDF of crypto engine, used by wrappers to forward Describer
+//
+// Params:
+// - impl {Crypto}
+//
+// Response:
+// - {KDFParams} zero if engine doesn't describe its KDF
+func KDFParamsOf(impl Crypto) KDFParams {
+	if d, ok := impl.(Describer); ok {
+		return d.KDFParams()
+	}
+
+	return KDFParams{}
+}
+
+// MacModeOf function: mac of crypto engine, used by wrappers to forward Describer
+//
+// Params:
+// - impl {Crypto}
+//
+// Response:
+// - {MacMode} UnknownMac if engine doesn't describe its mac
+func MacModeOf(impl Crypto) MacMode {
+	if d, ok := impl.(Describer); ok {
+		return d.MacMode()
+	}
+
+	return UnknownMac
+}
@@ -0,0 +1,86 @@
+package crypto
+
+import (
+	"crypto"
+	"io"
+	"log"
+	"sync"
+
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Entropy struct: crypto wrapper with explicitly injected entropy source
+//
+// Edge and serverless WASM runtimes may lack full crypto/rand semantics,
+// so random values (salts, secret ephemeral values a and b) are read from
+// the source provided by the host instead:
+//
+//   crypto := crypto.NewEntropy(crypto.NewStandard(hash.SHA256), hostRandom)
+//
+// Source MUST be a cryptographically secure generator. Reads are serialized,
+// so source doesn't have to be safe for concurrent use. Random never returns
+// short or partial values: failed read of the source is fatal.
+//
+// Hash, KDFParams and MacMode are forwarded to the wrapped engine,
+// so policies see Entropy the same way as the engine itself.
+type Entropy struct {
+	Crypto
+	mutex  *sync.Mutex
+	source io.Reader
+}
+
+// NewEntropy function Constructor
+//
+// Params:
+// - crypto {esrp.Crypto} crypto engine to wrap
+// - source {io.Reader} cryptographically secure entropy source
+//
+// Response:
+// - {Entropy}
+func NewEntropy(crypto Crypto, source io.Reader) Entropy {
+	return Entropy{Crypto: crypto, mutex: &sync.Mutex{}, source: source}
+}
+
+// Random function: random string generator reading injected source
+//
+// Params:
+// - bytesLength {int} length of desired generated bytes
+//
+// Response:
+// - {esrp.Value}
+func (e Entropy) Random(bytesLength int) v.Value {
+	buff := make([]byte, bytesLength)
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if _, err := io.ReadFull(e.source, buff); err != nil {
+		log.Fatal(err)
+	}
+
+	return v.New(buff)
+}
+
+// Hash function: hash type of wrapped engine
+//
+// Response:
+// - {crypto.Hash} zero if wrapped engine doesn't describe its hash
+func (e Entropy) Hash() crypto.Hash {
+	return HashOf(e.Crypto)
+}
+
+// KDFParams function: password-based key derivation parameters of wrapped engine
+//
+// Response:
+// - {KDFParams} zero if wrapped engine doesn't describe its KDF
+func (e Entropy) KDFParams() KDFParams {
+	return KDFParamsOf(e.Crypto)
+}
+
+// MacMode function: keyed hash transform of wrapped engine
+//
+// Response:
+// - {MacMode} UnknownMac if wrapped engine doesn't describe its mac
+func (e Entropy) MacMode() MacMode {
+	return MacModeOf(e.Crypto)
+}
@@ -0,0 +1,45 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"testing"
+)
+
+func TestEntropyRandom(t *testing.T) {
+	source := bytes.NewReader([]byte{1, 2, 3, 4, 5, 6})
+	instance := NewEntropy(NewStandard(crypto.SHA256), source)
+
+	if instance.Random(4).Hex() != "01020304" || instance.Random(2).Hex() != "0506" {
+		t.Error("random should be read from source")
+	}
+}
+
+func TestEntropyConformance(t *testing.T) {
+	if err := Conformance(NewEntropy(NewStandard(crypto.SHA256), rand.Reader)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEntropyDescribesWrapped(t *testing.T) {
+	for _, standard := range []Standard{
+		NewStandard(crypto.SHA256),
+		NewStandardWithParams(crypto.SHA512, StandardParams{Mac: Kmac256}),
+		NewStandardWithParams(crypto.SHA1, StandardParams{Iterations: 1000}),
+	} {
+		instance := NewEntropy(standard, rand.Reader)
+
+		if instance.Hash() != standard.Hash() ||
+			instance.KDFParams() != standard.KDFParams() ||
+			instance.MacMode() != standard.MacMode() {
+			t.Errorf("entropy should describe wrapped %s", standard.Hash())
+		}
+	}
+
+	instance := NewEntropy(struct{ Crypto }{NewStandard(crypto.SHA256)}, rand.Reader)
+
+	if instance.Hash() != 0 || instance.KDFParams() != (KDFParams{}) || instance.MacMode() != UnknownMac {
+		t.Error("entropy should not guess description of undescribed engine")
+	}
+}
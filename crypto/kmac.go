@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// kmacRate is cSHAKE256 block size in bytes
+const kmacRate = 136
+
+// kmacLength is KMAC256 output length in bytes (512 bits)
+const kmacLength = 64
+
+// kmac256 function: KMAC256 (NIST SP 800-185)
+//
+//   KMAC256(K, X, L, S) = cSHAKE256(bytepad(encode_string(K), 136) | X | right_encode(L), L, "KMAC", S)
+//
+// Params:
+// - key    {[]byte} secret key (K)
+// - msg    {[]byte} message (X)
+// - length {int}    output length in bytes (L)
+// - custom {[]byte} customization string (S)
+//
+// Response:
+// - {[]byte}
+func kmac256(key, msg []byte, length int, custom []byte) []byte {
+	hash := sha3.NewCShake256([]byte("KMAC"), custom)
+	hash.Write(bytepad(encodeString(key), kmacRate))
+	hash.Write(msg)
+	hash.Write(rightEncode(uint64(length) * 8))
+
+	out := make([]byte, length)
+	hash.Read(out)
+
+	return out
+}
+
+// bytepad function: prepend left_encode(w) and pad with zeros up to multiple of w
+func bytepad(value []byte, w int) []byte {
+	out := append(leftEncode(uint64(w)), value...)
+
+	if rest := len(out) % w; rest != 0 {
+		out = append(out, make([]byte, w-rest)...)
+	}
+
+	return out
+}
+
+// encodeString function: left_encode of bit length followed by value
+func encodeString(value []byte) []byte {
+	return append(leftEncode(uint64(len(value))*8), value...)
+}
+
+// leftEncode function: minimal big-endian bytes of x prefixed with their count
+func leftEncode(x uint64) []byte {
+	out := encodeUint(x)
+	return append([]byte{byte(len(out))}, out...)
+}
+
+// rightEncode function: minimal big-endian bytes of x followed by their count
+func rightEncode(x uint64) []byte {
+	out := encodeUint(x)
+	return append(out, byte(len(out)))
+}
+
+// encodeUint function: minimal big-endian bytes of x, at least one byte
+func encodeUint(x uint64) []byte {
+	out := []byte{}
+
+	for x > 0 {
+		out = append([]byte{byte(x)}, out...)
+		x >>= 8
+	}
+
+	if len(out) == 0 {
+		out = []byte{0}
+	}
+
+	return out
+}
@@ -0,0 +1,55 @@
+package crypto
+
+import (
+	"crypto"
+	"encoding/hex"
+	"testing"
+
+	"github.com/nsheremet/esrp/value"
+)
+
+// NIST SP 800-185 KMAC256 samples
+func kmacKey() []byte {
+	key := make([]byte, 32)
+
+	for i := range key {
+		key[i] = byte(0x40 + i)
+	}
+
+	return key
+}
+
+func kmacData(length int) []byte {
+	data := make([]byte, length)
+
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	return data
+}
+
+func TestKmac256Sample4(t *testing.T) {
+	subj := kmac256(kmacKey(), kmacData(4), 64, []byte("My Tagged Application"))
+
+	if hex.EncodeToString(subj) != "20c570c31346f703c9ac36c61c03cb64c3970d0cfc787e9b79599d273a68d2f7f69d4cc3de9d104a351689f27cf6f5951f0103f33f4f24871024d9c27773a8dd" {
+		t.Error("should be equal to NIST sample #4")
+	}
+}
+
+func TestKmac256Sample5(t *testing.T) {
+	subj := kmac256(kmacKey(), kmacData(200), 64, nil)
+
+	if hex.EncodeToString(subj) != "75358cf39e41494e949707927cee0af20a3ff553904c86b08f21cc414bcfd691589d27cf5e15369cbbff8b9a4c2eb17800855d0235ff635da82533ec6b759b69" {
+		t.Error("should be equal to NIST sample #5")
+	}
+}
+
+func TestStandardKeyedHashKmac256(t *testing.T) {
+	instance := NewStandardWithParams(crypto.SHA256, StandardParams{Mac: Kmac256})
+	subj := instance.KeyedHash(value.New(kmacKey()), value.New(kmacData(200)))
+
+	if subj.Hex() != "75358cf39e41494e949707927cee0af20a3ff553904c86b08f21cc414bcfd691589d27cf5e15369cbbff8b9a4c2eb17800855d0235ff635da82533ec6b759b69" {
+		t.Error("should be equal to NIST sample #5")
+	}
+}
@@ -0,0 +1,69 @@
+//go:build insecure_legacy
+// +build insecure_legacy
+
+package crypto
+
+import (
+	"crypto"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// Legacy hash implementations registered for crypto.Hash
+	_ "crypto/md5"
+
+	_ "golang.org/x/crypto/ripemd160"
+)
+
+// ForceInsecureLegacy {bool} allow legacy hashes outside of test binaries
+//
+// MD5 and RIPEMD-160 are broken or weak and must not protect new credentials.
+// They are only compiled with `insecure_legacy` build tag and are meant for
+// reverse-engineering and migrating old deployments. Set to true to use them
+// in a regular binary (e.g. one-off migration tool).
+var ForceInsecureLegacy = false
+
+// ErrUnsupportedLegacyHash {error} hash is not a legacy one
+var ErrUnsupportedLegacyHash = errors.New("crypto: unsupported legacy hash, expected MD5 or RIPEMD160")
+
+// NewInsecureLegacy public function: Standard crypto with legacy hash
+//
+// Params:
+// - hash {crypto.Hash} MD5 or RIPEMD160
+//
+// Response:
+// - {Standard}
+// - {error} ErrInsecureLegacy outside of test binary unless forced
+func NewInsecureLegacy(hash crypto.Hash) (Standard, error) {
+	if !legacyHash(hash) {
+		return Standard{}, ErrUnsupportedLegacyHash
+	}
+
+	if !allowLegacy() {
+		return Standard{}, ErrInsecureLegacy
+	}
+
+	return NewStandard(hash), nil
+}
+
+// refuseLegacy function: panic if legacy hash is not allowed
+func refuseLegacy(hash crypto.Hash) {
+	if legacyHash(hash) && !allowLegacy() {
+		panic(ErrInsecureLegacy)
+	}
+}
+
+// allowLegacy function: true if forced or running inside `go test` binary
+func allowLegacy() bool {
+	return ForceInsecureLegacy || testBinary()
+}
+
+// testBinary {func() bool} true if running inside `go test` binary
+//
+// Test flags are registered only after package initialization, so binary
+// name is checked instead: allowed legacy engines may be constructed from
+// package-level variables of tests too.
+var testBinary = func() bool {
+	return strings.HasSuffix(strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe"), ".test")
+}
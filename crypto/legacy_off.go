@@ -0,0 +1,15 @@
+//go:build !insecure_legacy
+// +build !insecure_legacy
+
+package crypto
+
+import (
+	"crypto"
+)
+
+// refuseLegacy function: panic on legacy hash, they require `insecure_legacy` build tag
+func refuseLegacy(hash crypto.Hash) {
+	if legacyHash(hash) {
+		panic(ErrInsecureLegacy)
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !insecure_legacy
+// +build !insecure_legacy
+
+package crypto
+
+import (
+	"crypto"
+	"testing"
+)
+
+func TestStandardRefusesLegacy(t *testing.T) {
+	for _, hash := range []crypto.Hash{crypto.MD5, crypto.RIPEMD160} {
+		func() {
+			defer func() {
+				if recover() != ErrInsecureLegacy {
+					t.Errorf("%s should be refused without insecure_legacy tag", hash)
+				}
+			}()
+
+			NewStandard(hash)
+		}()
+	}
+}
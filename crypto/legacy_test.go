@@ -0,0 +1,83 @@
+//go:build insecure_legacy
+// +build insecure_legacy
+
+package crypto
+
+import (
+	"crypto"
+	"testing"
+)
+
+// initLegacyErr {error} legacy engine constructed during package initialization
+var _, initLegacyErr = NewInsecureLegacy(crypto.MD5)
+
+func TestInsecureLegacyDuringInit(t *testing.T) {
+	if initLegacyErr != nil {
+		t.Error("test binary should be detected during package initialization")
+	}
+}
+
+func TestInsecureLegacyOutsideTests(t *testing.T) {
+	defer func(original func() bool) { testBinary = original }(testBinary)
+	testBinary = func() bool { return false }
+
+	if _, err := NewInsecureLegacy(crypto.MD5); err != ErrInsecureLegacy {
+		t.Error("should refuse legacy hash outside of tests")
+	}
+
+	for _, construct := range []func(){
+		func() { NewStandard(crypto.MD5) },
+		func() { NewStandardWithParams(crypto.RIPEMD160, StandardParams{LegacyKdf: true, Mac: LegacyConcat}) },
+		func() { NewStandardWithParams(crypto.MD5, StandardParams{Mac: Blake2Keyed}) },
+		func() { NewStandardWithParams(crypto.RIPEMD160, StandardParams{Iterations: 1000}) },
+	} {
+		if !refused(construct) {
+			t.Error("constructors should refuse legacy hash outside of tests")
+		}
+	}
+
+	ForceInsecureLegacy = true
+	defer func() { ForceInsecureLegacy = false }()
+
+	if refused(func() { NewStandard(crypto.MD5) }) {
+		t.Error("forced legacy hash should be allowed")
+	}
+}
+
+// refused function: true if construct panics with ErrInsecureLegacy
+func refused(construct func()) (ok bool) {
+	defer func() { ok = recover() == ErrInsecureLegacy }()
+	construct()
+
+	return false
+}
+
+func TestInsecureLegacyHWithMD5(t *testing.T) {
+	instance, err := NewInsecureLegacy(crypto.MD5)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if instance.H(val).Hex() != "4983359f1164b6326e40918bd2831a0b" {
+		t.Error("hash should be equal")
+	}
+}
+
+func TestInsecureLegacyHWithRIPEMD160(t *testing.T) {
+	instance, err := NewInsecureLegacy(crypto.RIPEMD160)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if instance.H(val).Hex() != "26226820c9a0c07d2667ce597f2fb476a4b152da" {
+		t.Error("hash should be equal")
+	}
+}
+
+func TestInsecureLegacyUnsupported(t *testing.T) {
+	if _, err := NewInsecureLegacy(crypto.SHA256); err != ErrUnsupportedLegacyHash {
+		t.Error("should refuse non-legacy hash")
+	}
+}
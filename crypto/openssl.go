@@ -1,3 +1,6 @@
+//go:build !tinygo && !esrp_tiny && !wasip1
+// +build !tinygo,!esrp_tiny,!wasip1
+
 package crypto
 
 import (
@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	v "github.com/nsheremet/esrp/value"
+)
+
+// LeftPad function: PAD() primitive
+//
+// Left-pads value with zero bytes up to the given length, as RFC5054
+// PAD() does (usually up to byte length of N). Values which are already
+// long enough are returned as is, they are never truncated.
+//
+//   LeftPad(0x0102, 4) = 0x00000102
+//
+// Params:
+// - value  {esrp.Value}
+// - length {int} desired length in bytes
+//
+// Response:
+// - {esrp.Value}
+func LeftPad(value v.Value, length int) v.Value {
+	return v.New(pad(value.Bytes(), length))
+}
+
+// pad function: implements byte padding
+//
+// Params:
+// - value  {[]byte}
+// - length {int}
+//
+// Response:
+// - {[]byte}
+func pad(value []byte, length int) []byte {
+	if len(value) >= length {
+		return value
+	}
+
+	buff := make([]byte, length)
+	copy(buff[length-len(value):], value)
+
+	return buff
+}
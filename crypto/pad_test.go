@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/nsheremet/esrp/value"
+)
+
+var leftPadVectors = []struct {
+	value    string
+	length   int
+	expected string
+}{
+	{"", 0, ""},
+	{"", 1, "00"},
+	{"", 4, "00000000"},
+	{"00", 1, "00"},
+	{"01", 0, "01"},
+	{"01", 1, "01"},
+	{"01", 2, "0001"},
+	{"0102", 1, "0102"},
+	{"0102", 2, "0102"},
+	{"0102", 3, "000102"},
+	{"0102", 4, "00000102"},
+	{"0002", 4, "00000002"},
+	{"ffffff", 2, "ffffff"},
+	{"ffffff", 8, "0000000000ffffff"},
+	{"07c0", 20, "00000000000000000000000000000000000007c0"},
+	{"010203040506070809", 9, "010203040506070809"},
+	{"010203040506070809", 10, "00010203040506070809"},
+}
+
+func TestLeftPad(t *testing.T) {
+	for _, vector := range leftPadVectors {
+		subj := LeftPad(value.New(vector.value), vector.length)
+
+		if subj.Hex() != vector.expected {
+			t.Errorf("LeftPad(%q, %d) should be %q, got %q", vector.value, vector.length, vector.expected, subj.Hex())
+		}
+	}
+}
+
+func TestLeftPadDoesNotModifyValue(t *testing.T) {
+	val := value.New("0102")
+	LeftPad(val, 4)
+
+	if val.Hex() != "0102" {
+		t.Error("value should not be modified")
+	}
+}
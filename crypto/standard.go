@@ -1,12 +1,21 @@
 package crypto
 
 import (
-	"bytes"
 	"crypto"
 	"crypto/hmac"
 	"crypto/rand"
+	"crypto/subtle"
+	"errors"
 	"log"
 
+	// Hash implementations registered for crypto.Hash
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	_ "golang.org/x/crypto/blake2b"
+	_ "golang.org/x/crypto/blake2s"
+	_ "golang.org/x/crypto/sha3"
+
 	"github.com/nsheremet/esrp/value"
 	v "github.com/nsheremet/esrp/value"
 	"golang.org/x/crypto/pbkdf2"
@@ -15,49 +24,132 @@ import (
 // Standard struct: Golang Stdlib crypto engine
 //
 // Provides:
-// - hash: SHA1, SHA256, SHA348, SHA512
-// - mac: hmac with selected hash
+// - hash: SHA1, SHA-2 family (SHA224, SHA256, SHA384, SHA512,
+//         SHA512_224, SHA512_256), SHA-3 family (SHA3_224, SHA3_256,
+//         SHA3_384, SHA3_512), BLAKE2 (BLAKE2b_256, BLAKE2b_384,
+//         BLAKE2b_512, BLAKE2s_256)
+// - kdf: pbkdf2 with selected hash (see StandardParams),
+//        legacy implementation H(salt | password)
+// - mac: hmac with selected hash, KMAC256 or keyed BLAKE2 (see MacMode)
 //
 // Defaults to SHA256_HMAC
 type Standard struct {
 	hasher    crypto.Hash
 	kdfIter   int
 	legacyKdf bool
-	legacyMac bool
+	mac       MacMode
+}
+
+// MacMode type: keyed hash transform used by KeyedHash
+//
+// HmacKeyMessage - HMAC(key, msg), default
+// HmacMessageKey - HMAC(msg, key), message is used as HMAC key,
+//                  as seen in some implementations
+// LegacyConcat   - H(msg | key), legacy implementation
+// Kmac256        - KMAC256(key, msg) with 512-bit output and empty
+//                  customization string (NIST SP 800-185), hash is ignored
+// Blake2Keyed    - keyed BLAKE2(key, msg) (RFC7693) instead of HMAC,
+//                  BLAKE2s for BLAKE2s_256 hash, BLAKE2b otherwise,
+//                  output length is equal to hash size
+// UnknownMac     - mac of crypto engine not describing itself (see Entropy),
+//                  not a valid mode for Standard
+type MacMode int
+
+// Mac modes
+const (
+	HmacKeyMessage MacMode = iota
+	HmacMessageKey
+	LegacyConcat
+	Kmac256
+	Blake2Keyed
+	UnknownMac
+)
+
+// DefaultKdfIterations {int} PBKDF2 iterations count used by constructors
+const DefaultKdfIterations = 20000
+
+// ErrInsecureLegacy {error} legacy hash requested outside of test binary
+//
+// Constructors panic with it when given MD5 or RIPEMD160, unless built with
+// `insecure_legacy` tag and allowed (see NewInsecureLegacy).
+var ErrInsecureLegacy = errors.New("crypto: insecure legacy hash refused, build with -tags insecure_legacy and set ForceInsecureLegacy")
+
+// legacyHash function: true if hash is MD5 or RIPEMD160
+func legacyHash(hash crypto.Hash) bool {
+	return hash == crypto.MD5 || hash == crypto.RIPEMD160
 }
 
 // NewStandard public function:
 //
+// Panics with ErrInsecureLegacy if legacy hash is refused, as other
+// constructors do.
+//
 // Params:
-// - hash {crypto.Hash} Hash type, example: SHA1, SHA256, SHA512
+// - hash {crypto.Hash} Hash type, example: SHA1, SHA256, SHA512, SHA512_256, SHA3_256
 //
 // Response:
 // - {Standard}
 func NewStandard(hash crypto.Hash) Standard {
+	refuseLegacy(hash)
+
 	return Standard{
 		hasher:  hash,
-		kdfIter: 20000,
+		kdfIter: DefaultKdfIterations,
 	}
 }
 
+// StandardParams struct: Standard crypto options, zero value is the default
+//
+// Provides:
+// LegacyKdf  - if true, PasswordHash is H(salt | password) instead of PBKDF2
+// Mac        - keyed hash transform (see MacMode)
+// Iterations - PBKDF2 iterations count, should be tuned to the hardware,
+//              DefaultKdfIterations is used if not positive
+type StandardParams struct {
+	LegacyKdf  bool
+	Mac        MacMode
+	Iterations int
+}
+
 // NewStandardWithParams public function:
 //
 // Params:
-// - hash {crypto.Hash} Hash type
-// - kdf {bool} - if true, using Legacy Kdf
-// - mac {bool} - if true, using Legacy Mac
+// - hash   {crypto.Hash} Hash type
+// - params {StandardParams} combined KDF and mac options
 //
 // Response:
 // - {Standard}
-func NewStandardWithParams(hash crypto.Hash, kdf, mac bool) Standard {
+func NewStandardWithParams(hash crypto.Hash, params StandardParams) Standard {
+	refuseLegacy(hash)
+
+	if params.Iterations <= 0 {
+		params.Iterations = DefaultKdfIterations
+	}
+
 	return Standard{
 		hasher:    hash,
-		kdfIter:   20000,
-		legacyKdf: kdf,
-		legacyMac: mac,
+		kdfIter:   params.Iterations,
+		legacyKdf: params.LegacyKdf,
+		mac:       params.Mac,
 	}
 }
 
+// Hash public function: hash type used by H, PasswordHash and KeyedHash
+//
+// Response:
+// - {crypto.Hash}
+func (s Standard) Hash() crypto.Hash {
+	return s.hasher
+}
+
+// MacMode public function: keyed hash transform used by KeyedHash
+//
+// Response:
+// - {MacMode}
+func (s Standard) MacMode() MacMode {
+	return s.mac
+}
+
 // H public function:
 //
 // Params:
@@ -106,6 +198,34 @@ func (s Standard) PasswordHash(salt v.Value, password string) v.Value {
 	))
 }
 
+// KDFParams struct: password-based key derivation parameters
+//
+// Provides:
+// Name       - "pbkdf2", "argon2id" or "legacy" for H(salt | password)
+// Hash       - hash name, e.g. "SHA-256"
+// Iterations - iterations count (time for argon2id), zero for legacy
+// Memory     - memory size in KiB, argon2id only
+// Threads    - degree of parallelism, argon2id only
+type KDFParams struct {
+	Name       string
+	Hash       string
+	Iterations int
+	Memory     uint32
+	Threads    uint8
+}
+
+// KDFParams public function: parameters used by PasswordHash
+//
+// Response:
+// - {KDFParams}
+func (s Standard) KDFParams() KDFParams {
+	if s.legacyKdf {
+		return KDFParams{Name: "legacy", Hash: s.hasher.String()}
+	}
+
+	return KDFParams{Name: "pbkdf2", Hash: s.hasher.String(), Iterations: s.kdfIter}
+}
+
 // KeyedHash public function: keyed hash transform function
 //
 // Params:
@@ -115,12 +235,19 @@ func (s Standard) PasswordHash(salt v.Value, password string) v.Value {
 // Response:
 // - esrp.Value
 func (s Standard) KeyedHash(key, msg v.Value) v.Value {
-	if s.legacyMac {
+	switch s.mac {
+	case LegacyConcat:
 		hash := s.hasher.New()
 		hash.Write(msg.Bytes())
 		hash.Write(key.Bytes())
 
 		return v.New(hash.Sum(nil))
+	case Kmac256:
+		return v.New(kmac256(key.Bytes(), msg.Bytes(), kmacLength, nil))
+	case Blake2Keyed:
+		return v.New(blake2Mac(s.hasher, key.Bytes(), msg.Bytes()))
+	case HmacMessageKey:
+		key, msg = msg, key
 	}
 
 	hash := hmac.New(s.hasher.New, key.Bytes())
@@ -153,28 +280,5 @@ func (s Standard) Random(bytesLength int) v.Value {
 // Response:
 // - {bool} true if strings are equal
 func (s Standard) SecureCompare(a v.Value, b v.Value) bool {
-	return bytes.Equal(a.Bytes(), b.Bytes())
-}
-
-// pad function: implements byte padding
-//
-// Params:
-// - value  {[]byte}
-// - length {int}
-//
-// Response:
-// - {[]byte}
-func pad(value []byte, length int) []byte {
-	if len(value) >= length {
-		return value
-	}
-
-	size := 1
-
-	for ((len(value) + size) % length) != 0 {
-		size = size + 1
-	}
-
-	pad := bytes.Repeat([]byte{byte(0)}, size)
-	return append(pad, value...)
+	return subtle.ConstantTimeCompare(a.Bytes(), b.Bytes()) == 1
 }
@@ -49,6 +49,42 @@ func TestStandardHWithSHA512(t *testing.T) {
 	}
 }
 
+func TestStandardHWithSHA224(t *testing.T) {
+	instance := NewStandard(crypto.SHA224)
+	subj := instance.H(val)
+
+	if subj.Hex() != "d9b2da91bb312b298d043ea1841e1d447bfcc1c5613b39fef63c086b" {
+		t.Error("hash should be equal")
+	}
+}
+
+func TestStandardHWithSHA512_256(t *testing.T) {
+	instance := NewStandard(crypto.SHA512_256)
+	subj := instance.H(val)
+
+	if subj.Hex() != "12f6c1b4bef90089622df1bdc55562d064867677fd02bbbf3bc2fc1e90592943" {
+		t.Error("hash should be equal")
+	}
+}
+
+func TestStandardHWithSHA3_256(t *testing.T) {
+	instance := NewStandard(crypto.SHA3_256)
+	subj := instance.H(val)
+
+	if subj.Hex() != "eaf644a6188c3d5c24d3e3e0df05d9dcf2f1050b14294eff951f75646b981fa8" {
+		t.Error("hash should be equal")
+	}
+}
+
+func TestStandardHWithSHA3_512(t *testing.T) {
+	instance := NewStandard(crypto.SHA3_512)
+	subj := instance.H(val)
+
+	if subj.Hex() != "77252574bb18a6db59438da86c1ac94ae93edf7e5b07182ee339d710b5169177aa8a6ae2d44f3c5aef8561bcf2aa685595d07fe3c0c7b0682b87b1072623be8a" {
+		t.Error("hash should be equal")
+	}
+}
+
 var salt = value.New(big.NewInt(1117))
 var password = "verysecure"
 
@@ -70,6 +106,42 @@ func TestStandardPasswordHashWithSHA256(t *testing.T) {
 	}
 }
 
+func TestStandardPasswordHashWithSHA224(t *testing.T) {
+	instance := NewStandard(crypto.SHA224)
+	subj := instance.PasswordHash(salt, password)
+
+	if subj.Hex() != "7d9aaab7ff3da2cecea9c79eb78032ebd6e8cca17a10f8e826e480c1" {
+		t.Error("should be equal")
+	}
+}
+
+func TestStandardPasswordHashWithSHA512_256(t *testing.T) {
+	instance := NewStandard(crypto.SHA512_256)
+	subj := instance.PasswordHash(salt, password)
+
+	if subj.Hex() != "ec9aa40572bb003f72d4360163d08e3d655f58fa77d514ed24143d9525a65b15" {
+		t.Error("should be equal")
+	}
+}
+
+func TestStandardPasswordHashWithSHA3_256(t *testing.T) {
+	instance := NewStandard(crypto.SHA3_256)
+	subj := instance.PasswordHash(salt, password)
+
+	if subj.Hex() != "e45f43d70e1129e4da2e1e42fa7c21efce89477232fe7ee158f84ce71b43c475" {
+		t.Error("should be equal")
+	}
+}
+
+func TestStandardPasswordHashWithSHA3_512(t *testing.T) {
+	instance := NewStandard(crypto.SHA3_512)
+	subj := instance.PasswordHash(salt, password)
+
+	if subj.Hex() != "0942470a531c1de1e81e38cded476767e08a922d7547020549335ad5a06d26bfba4289919cf21ce76a9f7ca3c472d2b66ea66db8eec60a1f592b69294a65d744" {
+		t.Error("should be equal")
+	}
+}
+
 func TestStandardPasswordHashWithSHA384(t *testing.T) {
 	instance := NewStandard(crypto.SHA384)
 	subj := instance.PasswordHash(salt, password)
@@ -91,8 +163,40 @@ func TestStandardPasswordHashWithSHA512(t *testing.T) {
 var key = value.New("f4ffd830b255f778b9d88966e87ae1d72702227cfcbeae4bd1e4b39fff136060")
 var msg = value.New("07c0")
 
+func TestStandardPasswordHashWithIterations(t *testing.T) {
+	// RFC6070 test vector
+	instance := NewStandardWithParams(crypto.SHA1, StandardParams{Iterations: 4096})
+	subj := instance.PasswordHash(value.New([]byte("salt")), "password")
+
+	if subj.Hex() != "4b007901b765489abead49d926f721d065a429c1" {
+		t.Error("hash should be equal")
+	}
+
+	if NewStandardWithParams(crypto.SHA1, StandardParams{Iterations: 0}).KDFParams().Iterations != DefaultKdfIterations {
+		t.Error("default iterations should be used")
+	}
+}
+
+func TestStandardWithCombinedParams(t *testing.T) {
+	instance := NewStandardWithParams(crypto.SHA256, StandardParams{Mac: Kmac256, Iterations: 4096})
+
+	if instance.KDFParams() != (KDFParams{Name: "pbkdf2", Hash: "SHA-256", Iterations: 4096}) {
+		t.Error("iterations should be kept with mac mode")
+	}
+
+	if instance.MacMode() != Kmac256 {
+		t.Error("mac mode should be kept with iterations")
+	}
+
+	legacy := NewStandardWithParams(crypto.SHA256, StandardParams{LegacyKdf: true, Mac: LegacyConcat})
+
+	if legacy.KDFParams().Name != "legacy" || legacy.MacMode() != LegacyConcat {
+		t.Error("legacy kdf and mac should be combined")
+	}
+}
+
 func TestStandardPasswordHashLegacyWithSHA1(t *testing.T) {
-	instance := NewStandardWithParams(crypto.SHA1, true, false)
+	instance := NewStandardWithParams(crypto.SHA1, StandardParams{LegacyKdf: true})
 	subj := instance.PasswordHash(salt, password)
 
 	if subj.Hex() != "4fb8f49a9526730f9b49ae5915011fd43c0dd598" {
@@ -101,7 +205,7 @@ func TestStandardPasswordHashLegacyWithSHA1(t *testing.T) {
 }
 
 func TestStandardPasswordHashLegacyWithSHA256(t *testing.T) {
-	instance := NewStandardWithParams(crypto.SHA256, true, false)
+	instance := NewStandardWithParams(crypto.SHA256, StandardParams{LegacyKdf: true})
 	subj := instance.PasswordHash(salt, password)
 
 	if subj.Hex() != "ee36a8a3b95a6d3e02680b603f71f71e911a6f69c384aa0d18bd03f18c810d1f" {
@@ -110,7 +214,7 @@ func TestStandardPasswordHashLegacyWithSHA256(t *testing.T) {
 }
 
 func TestStandardPasswordHashLegacyWithSHA384(t *testing.T) {
-	instance := NewStandardWithParams(crypto.SHA384, true, false)
+	instance := NewStandardWithParams(crypto.SHA384, StandardParams{LegacyKdf: true})
 	subj := instance.PasswordHash(salt, password)
 
 	if subj.Hex() != "3b9b35652dd6c98a73b31cf9e020482a4d2400632601cc7e9cc095952b7434c7214a4b6657fe7ba4c1d6bca8e1cb6c9a" {
@@ -119,7 +223,7 @@ func TestStandardPasswordHashLegacyWithSHA384(t *testing.T) {
 }
 
 func TestStandardPasswordHashLegacyWithSHA512(t *testing.T) {
-	instance := NewStandardWithParams(crypto.SHA512, true, false)
+	instance := NewStandardWithParams(crypto.SHA512, StandardParams{LegacyKdf: true})
 	subj := instance.PasswordHash(salt, password)
 
 	if subj.Hex() != "d17cf68960f86086ca789d7e56e3fd050a8848ccbf5d7034ce449c8a897c6b6932c76e20a48e4cc4898e7fd436b93c6dcc8f852cb498f156e4aed9c096bfd279" {
@@ -164,7 +268,7 @@ func TestStandardKeyedHashWithSHA512(t *testing.T) {
 }
 
 func TestStandardKeyedHashLegacySHA1(t *testing.T) {
-	instance := NewStandardWithParams(crypto.SHA1, false, true)
+	instance := NewStandardWithParams(crypto.SHA1, StandardParams{Mac: LegacyConcat})
 	subj := instance.KeyedHash(key, msg)
 
 	if subj.Hex() != "370422c37f40c245bcc614c733ad39c7b796bed6" {
@@ -173,7 +277,7 @@ func TestStandardKeyedHashLegacySHA1(t *testing.T) {
 }
 
 func TestStandardKeyedHashLegacySHA256(t *testing.T) {
-	instance := NewStandardWithParams(crypto.SHA256, false, true)
+	instance := NewStandardWithParams(crypto.SHA256, StandardParams{Mac: LegacyConcat})
 	subj := instance.KeyedHash(key, msg)
 
 	if subj.Hex() != "72cd133608ddfae3ebeb26b757c0b825bb4195c2153be5a7a543ed7212c18949" {
@@ -182,7 +286,7 @@ func TestStandardKeyedHashLegacySHA256(t *testing.T) {
 }
 
 func TestStandardKeyedHashLegacySHA384(t *testing.T) {
-	instance := NewStandardWithParams(crypto.SHA384, false, true)
+	instance := NewStandardWithParams(crypto.SHA384, StandardParams{Mac: LegacyConcat})
 	subj := instance.KeyedHash(key, msg)
 
 	if subj.Hex() != "8fb3c4a42f47946c0fb686670810462a8b87aa3eb49d491c73380bdeddd1799a94a2d8fd0114efea3f6de5edd00f91eb" {
@@ -190,6 +294,25 @@ func TestStandardKeyedHashLegacySHA384(t *testing.T) {
 	}
 }
 
+func TestStandardKeyedHashMessageKeySHA256(t *testing.T) {
+	instance := NewStandardWithParams(crypto.SHA256, StandardParams{Mac: HmacMessageKey})
+	subj := instance.KeyedHash(key, msg)
+
+	if subj.Hex() != "93adf7d7b762d917eb5bee795694d044d40f138508aa15dcdb9b6a0654e2b66e" {
+		t.Error("should be equal")
+	}
+}
+
+func TestStandardKeyedHashMacModes(t *testing.T) {
+	if NewStandardWithParams(crypto.SHA256, StandardParams{Mac: HmacKeyMessage}).KeyedHash(key, msg).Hex() != NewStandard(crypto.SHA256).KeyedHash(key, msg).Hex() {
+		t.Error("HmacKeyMessage should be default")
+	}
+
+	if NewStandardWithParams(crypto.SHA256, StandardParams{Mac: LegacyConcat}).KeyedHash(key, msg).Hex() != NewStandardWithParams(crypto.SHA256, StandardParams{Mac: LegacyConcat}).KeyedHash(key, msg).Hex() {
+		t.Error("LegacyConcat should be equal to legacy mac")
+	}
+}
+
 func TestStandardSecureCompareTrue(t *testing.T) {
 	instance := Standard{}
 	a := value.New("00ff3b16b0f555d3feb62f988fb3aab81c1c50ea")
@@ -208,6 +331,10 @@ func TestStandardSecureCompareFalse(t *testing.T) {
 	if instance.SecureCompare(a, b) {
 		t.Error("values should be equal")
 	}
+
+	if instance.SecureCompare(a, value.New("00ff3b16b0f555d3feb62f988fb3aab81c1c50")) {
+		t.Error("values of different length should not be equal")
+	}
 }
 
 func TestStandardRandom(t *testing.T) {
@@ -228,3 +355,13 @@ func TestStandardPadding(t *testing.T) {
 		t.Error("pad should make")
 	}
 }
+
+func TestStandardKDFParams(t *testing.T) {
+	if NewStandard(crypto.SHA256).KDFParams() != (KDFParams{Name: "pbkdf2", Hash: "SHA-256", Iterations: 20000}) {
+		t.Error("pbkdf2 params should be equal")
+	}
+
+	if NewStandardWithParams(crypto.SHA1, StandardParams{LegacyKdf: true}).KDFParams() != (KDFParams{Name: "legacy", Hash: "SHA-1"}) {
+		t.Error("legacy params should be equal")
+	}
+}
@@ -0,0 +1,184 @@
+package crypto
+
+import (
+	"crypto"
+	"sync"
+
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Trace struct: crypto wrapper recording every hash invocation
+//
+// Debug/audit mode for interop failures: build engine on top of traced crypto,
+// run the handshake and diff Report against the trace of another implementation.
+//
+//   trace := crypto.NewTrace(crypto.NewStandard(hash.SHA256))
+//   engine := engine.New(trace, group)
+//
+// Inputs of H are recorded exactly as they are hashed, i.e. after PAD() up to
+// length of the first value, which built-in crypto engines apply.
+// Values padded by engine beforehand (e.g. PAD(g) in k) are recorded as passed.
+//
+// Description of traced engine (Hasher, Describer) is passed through.
+//
+// IMPORTANT: report contains secret values (K, S, x), never enable it in production.
+type Trace struct {
+	Crypto
+	mutex   sync.Mutex
+	entries []TraceEntry
+}
+
+// TraceEntry struct: single recorded invocation
+//
+// Provides:
+// Func   - function name: "H", "KeyedHash" or "PasswordHash"
+// Inputs - hashed byte strings, in order
+// Output - result in hex
+type TraceEntry struct {
+	Func   string       `json:"func"`
+	Inputs []TraceInput `json:"inputs"`
+	Output string       `json:"output"`
+}
+
+// TraceInput struct: single hashed byte string
+//
+// Provides:
+// Hex    - bytes in hex, empty for password
+// Length - length in bytes
+// Padded - true if value was left-padded with zero bytes before hashing
+type TraceInput struct {
+	Hex    string `json:"hex"`
+	Length int    `json:"length"`
+	Padded bool   `json:"padded"`
+}
+
+// NewTrace function Constructor
+//
+// Params:
+// - crypto {esrp.Crypto} crypto engine to wrap
+//
+// Response:
+// - {*Trace}
+func NewTrace(crypto Crypto) *Trace {
+	return &Trace{Crypto: crypto}
+}
+
+// H function: one way hash function with recording
+//
+// Params:
+// - values {[]esrp.Value} values to be hashed
+//
+// Response:
+// - {esrp.Value}
+func (t *Trace) H(values ...v.Value) v.Value {
+	res := t.Crypto.H(values...)
+	inputs := make([]TraceInput, len(values))
+	l := len(values[0].Bytes())
+
+	for i, value := range values {
+		bytes := pad(value.Bytes(), l)
+		inputs[i] = TraceInput{Hex: v.New(bytes).Hex(), Length: len(bytes), Padded: len(bytes) != len(value.Bytes())}
+	}
+
+	t.record(TraceEntry{Func: "H", Inputs: inputs, Output: res.Hex()})
+
+	return res
+}
+
+// KeyedHash function: keyed hash transform with recording
+//
+// Params:
+// - key {esrp.Value}
+// - msg {esrp.Value}
+//
+// Response:
+// - {esrp.Value}
+func (t *Trace) KeyedHash(key, msg v.Value) v.Value {
+	res := t.Crypto.KeyedHash(key, msg)
+	t.record(TraceEntry{Func: "KeyedHash", Inputs: []TraceInput{input(key), input(msg)}, Output: res.Hex()})
+
+	return res
+}
+
+// PasswordHash function: password-based key derivation with recording
+//
+// Password itself is not recorded, only its length.
+//
+// Params:
+// - salt     {esrp.Value} random generated salt (s)
+// - password {string} plain-text password
+//
+// Response:
+// - {esrp.Value}
+func (t *Trace) PasswordHash(salt v.Value, password string) v.Value {
+	res := t.Crypto.PasswordHash(salt, password)
+	inputs := []TraceInput{input(salt), {Length: len(password)}}
+	t.record(TraceEntry{Func: "PasswordHash", Inputs: inputs, Output: res.Hex()})
+
+	return res
+}
+
+// Hash function: hash type of traced engine
+//
+// Response:
+// - {crypto.Hash} zero if traced engine doesn't describe its hash
+func (t *Trace) Hash() crypto.Hash {
+	return HashOf(t.Crypto)
+}
+
+// KDFParams function: password-based key derivation parameters of traced engine
+//
+// Response:
+// - {KDFParams} zero if traced engine doesn't describe its KDF
+func (t *Trace) KDFParams() KDFParams {
+	return KDFParamsOf(t.Crypto)
+}
+
+// MacMode function: keyed hash transform of traced engine
+//
+// Response:
+// - {MacMode} UnknownMac if traced engine doesn't describe its mac
+func (t *Trace) MacMode() MacMode {
+	return MacModeOf(t.Crypto)
+}
+
+// Report function: recorded invocations, in order
+//
+// Response:
+// - {[]TraceEntry}
+func (t *Trace) Report() []TraceEntry {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return append([]TraceEntry(nil), t.entries...)
+}
+
+// Reset function: drop recorded invocations
+func (t *Trace) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.entries = nil
+}
+
+// record function: append entry to report
+//
+// Params:
+// - entry {TraceEntry}
+func (t *Trace) record(entry TraceEntry) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.entries = append(t.entries, entry)
+}
+
+// input function: unpadded trace input
+//
+// Params:
+// - value {esrp.Value}
+//
+// Response:
+// - {TraceInput}
+func input(value v.Value) TraceInput {
+	return TraceInput{Hex: value.Hex(), Length: len(value.Bytes())}
+}
@@ -0,0 +1,63 @@
+package crypto
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestTraceH(t *testing.T) {
+	instance := NewStandard(crypto.SHA256)
+	trace := NewTrace(instance)
+	subj := trace.H(value.New("0102"), value.New("03"))
+
+	if subj.Hex() != instance.H(value.New("0102"), value.New("03")).Hex() {
+		t.Error("hash should be equal")
+	}
+
+	report := trace.Report()
+
+	if len(report) != 1 || report[0].Func != "H" || report[0].Output != subj.Hex() {
+		t.Fatal("invocation should be recorded")
+	}
+
+	expected := []TraceInput{{Hex: "0102", Length: 2}, {Hex: "0003", Length: 2, Padded: true}}
+
+	for i, in := range report[0].Inputs {
+		if in != expected[i] {
+			t.Errorf("input %d should be equal: %+v", i, in)
+		}
+	}
+}
+
+func TestTracePasswordHash(t *testing.T) {
+	trace := NewTrace(NewStandard(crypto.SHA256))
+	trace.PasswordHash(value.New("beb25379d1a8581eb5a727673a2441ee"), "password123")
+	trace.KeyedHash(value.New("07c0"), value.New("0102"))
+
+	report := trace.Report()
+
+	if len(report) != 2 || report[0].Func != "PasswordHash" || report[1].Func != "KeyedHash" {
+		t.Fatal("invocations should be recorded in order")
+	}
+
+	if report[0].Inputs[1] != (TraceInput{Length: 11}) {
+		t.Error("password should not be recorded")
+	}
+
+	trace.Reset()
+
+	if len(trace.Report()) != 0 {
+		t.Error("report should be empty after reset")
+	}
+}
+
+func TestTraceDescribesWrapped(t *testing.T) {
+	standard := NewStandardWithParams(crypto.SHA512, StandardParams{Mac: Kmac256})
+	trace := NewTrace(standard)
+
+	if trace.Hash() != standard.Hash() || trace.KDFParams() != standard.KDFParams() || trace.MacMode() != standard.MacMode() {
+		t.Error("trace should describe wrapped engine")
+	}
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	c "github.com/nsheremet/esrp/crypto"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// AccountKey is an engine mixing client-held account key into x
+//
+// Follows two-secret key derivation (2SKD) pattern: besides the password,
+// the client holds a high-entropy account key (secret key), which never leaves
+// the device and is mixed into x before verifier computation:
+//
+//   x = x' xor KeyedHash(AK, ID)
+//
+// where x' is private key (x) calculated by underlying engine,
+// AK is an account key and ID is an account identifier.
+// Verifier (v) stays protected even if the password is weak,
+// since the attacker also needs AK for offline guessing.
+type AccountKey struct {
+	Standard
+
+	key v.Value
+	id  string
+}
+
+// NewAccountKey function Constructor
+//
+// Params:
+// - engine {Standard} underlying engine
+// - key    {v.Value}  account key (AK)
+// - id     {string}   account identifier (ID)
+//
+// Response:
+// - {AccountKey}
+func NewAccountKey(engine Standard, key v.Value, id string) AccountKey {
+	return AccountKey{
+		Standard: engine,
+		key:      key,
+		id:       id,
+	}
+}
+
+// CalcX function: Calculate private key (x) mixed with account key
+//
+//   x = KDF(s, p) xor KeyedHash(AK, ID)
+//
+// Params:
+// - password {string} plain-text password in UTF8 string
+// - salt     {string} random generated salt (s)
+//
+// Returns: {v.Value} private key (x)
+func (e AccountKey) CalcX(password, salt string) v.Value {
+	x := e.Standard.CalcX(password, salt)
+	mask := e.crypto.KeyedHash(e.key, v.New([]byte(e.id)))
+
+	length := len(x.Bytes())
+	if len(mask.Bytes()) > length {
+		length = len(mask.Bytes())
+	}
+
+	xb := c.LeftPad(x, length).Bytes()
+	mb := c.LeftPad(mask, length).Bytes()
+	res := make([]byte, length)
+
+	for i := range res {
+		res[i] = xb[i] ^ mb[i]
+	}
+
+	return v.New(res)
+}
@@ -0,0 +1,42 @@
+package engine_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+var accountKey = value.New("a3f1c0b2d4e5f60718293a4b5c6d7e8f")
+
+func TestAccountKeyCalcX(t *testing.T) {
+	standard := e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)}
+	subj := e.NewAccountKey(standard, accountKey, "alice@example.com")
+
+	x := subj.CalcX("password123", "beb25379d1a8581eb5a727673a2441ee")
+	plain := standard.CalcX("password123", "beb25379d1a8581eb5a727673a2441ee")
+
+	if x.Hex() == plain.Hex() {
+		t.Error("account key should be mixed into x")
+	}
+
+	if len(x.Bytes()) != len(plain.Bytes()) {
+		t.Error("x length should be preserved")
+	}
+}
+
+func TestAccountKeyCalcXDiffersByKey(t *testing.T) {
+	standard := e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)}
+	first := e.NewAccountKey(standard, accountKey, "alice@example.com")
+	second := e.NewAccountKey(standard, value.New("00"), "alice@example.com")
+
+	if first.CalcX("password123", "beb2").Hex() == second.CalcX("password123", "beb2").Hex() {
+		t.Error("x should depend on account key")
+	}
+
+	if first.CalcX("password123", "beb2").Hex() != first.CalcX("password123", "beb2").Hex() {
+		t.Error("x should be deterministic")
+	}
+}
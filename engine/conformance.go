@@ -0,0 +1,21 @@
+package engine
+
+import (
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Conformable interface: engine.Interface implementation with SRP arithmetic
+//
+// Custom engines embedding Engine satisfy it once they implement Interface.
+// Implementations may be checked with esrptest.Conformance.
+type Conformable interface {
+	Interface
+	CalcV(x v.Value) v.Value
+	CalcA(a v.Value) v.Value
+	CalcB(b, val v.Value) v.Value
+	CalcU(aa, bb v.Value) v.Value
+	CalcClientS(bb, a, x, u v.Value) v.Value
+	CalcServerS(aa, b, val, u v.Value) v.Value
+	CalcK(ss v.Value) v.Value
+	IsValidPublic(value v.Value) bool
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"errors"
+	"io"
+
+	c "github.com/nsheremet/esrp/crypto"
+	v "github.com/nsheremet/esrp/value"
+	"golang.org/x/crypto/hkdf"
+)
+
+// maxDerivedKeys {int} HKDF output limit, 255 blocks of hash size
+const maxDerivedKeys = 255
+
+// ErrInvalidKeyCount is returned when DeriveKeys is asked for less than one or too many keys
+var ErrInvalidKeyCount = errors.New("engine: invalid number of derived keys")
+
+// ErrUnavailableHash is returned when crypto engine doesn't describe its hash
+// (see crypto.Hasher) or the hash is not linked into the binary
+var ErrUnavailableHash = errors.New("engine: unavailable hash for derived keys")
+
+// DeriveKeys function: expand premaster secret (S) into independent keys
+//
+//   OKM = HKDF(H, S, info)
+//   key[i] = OKM[i * len(H) : (i + 1) * len(H)]
+//
+// Lets applications use separate encryption and MAC keys from one
+// SRP exchange instead of using K everywhere. H is the hash of crypto engine,
+// so both peers derive equal keys; engines not describing their hash are
+// refused instead of guessing. Different info strings give unrelated keys,
+// so info should name the purpose of keys, e.g. "myapp v1 channel keys".
+//
+// Params:
+// - ss   {esrp.Value} premaster secret (S)
+// - info {[]byte} context and application specific information
+// - n    {int} number of keys, 1..255
+//
+// Response:
+// - {[]esrp.Value} n keys of hash size
+// - {error} ErrInvalidKeyCount if n is out of range,
+//           ErrUnavailableHash if hash of crypto engine is unknown
+func (e Engine) DeriveKeys(ss v.Value, info []byte, n int) ([]v.Value, error) {
+	if n < 1 || n > maxDerivedKeys {
+		return nil, ErrInvalidKeyCount
+	}
+
+	h := c.HashOf(e.crypto)
+
+	if !h.Available() {
+		return nil, ErrUnavailableHash
+	}
+
+	reader := hkdf.New(h.New, ss.Bytes(), nil, info)
+	keys := make([]v.Value, n)
+
+	for i := range keys {
+		key := make([]byte, h.Size())
+
+		if _, err := io.ReadFull(reader, key); err != nil {
+			return nil, err
+		}
+
+		keys[i] = v.New(key)
+	}
+
+	return keys, nil
+}
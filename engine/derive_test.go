@@ -0,0 +1,59 @@
+package engine_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestEngineDeriveKeys(t *testing.T) {
+	ss := value.New(vectors["S"])
+	keys, err := e.New(c.NewStandard(hash.SHA256), grp).DeriveKeys(ss, []byte("esrp test"), 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 2 ||
+		keys[0].Hex() != "896b2d83991e061bdbff7b0aabc9e9441c1104c70df755c405efb3d98d19e5ec" ||
+		keys[1].Hex() != "4df4cbe411b4eab2fa385dc3ba275205170d6c746c4e5c2aaf29413d062c5222" {
+		t.Error("keys should be equal")
+	}
+
+	keys, err = gnuTLS.DeriveKeys(ss, []byte("esrp test"), 1)
+
+	if err != nil || keys[0].Hex() != "6cb81d4d5f9131737ff0459abed3cf657ffb1787" {
+		t.Error("key should be derived with hash of crypto engine")
+	}
+}
+
+func TestEngineDeriveKeysWrappedCrypto(t *testing.T) {
+	ss := value.New(vectors["S"])
+	wrapped := c.NewTrace(c.NewStandard(hash.SHA1))
+	keys, err := e.New(wrapped, grp).DeriveKeys(ss, []byte("esrp test"), 1)
+
+	if err != nil || keys[0].Hex() != "6cb81d4d5f9131737ff0459abed3cf657ffb1787" {
+		t.Error("key should be derived with hash of wrapped engine, not with a default one")
+	}
+}
+
+func TestEngineDeriveKeysInvalidCount(t *testing.T) {
+	engine := e.New(c.NewStandard(hash.SHA256), grp)
+
+	for _, n := range []int{0, -1, 256} {
+		if _, err := engine.DeriveKeys(value.New(vectors["S"]), nil, n); err != e.ErrInvalidKeyCount {
+			t.Errorf("%d keys should be rejected", n)
+		}
+	}
+}
+
+func TestEngineDeriveKeysUnavailableHash(t *testing.T) {
+	undescribed := struct{ c.Crypto }{c.NewStandard(hash.SHA256)}
+
+	if _, err := e.New(undescribed, grp).DeriveKeys(value.New(vectors["S"]), nil, 1); err != e.ErrUnavailableHash {
+		t.Error("engine not describing its hash should be rejected")
+	}
+}
@@ -0,0 +1,146 @@
+package engine
+
+import (
+	"encoding/hex"
+
+	c "github.com/nsheremet/esrp/crypto"
+	g "github.com/nsheremet/esrp/group"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Encoding type: canonical encoding of values fed into H and KeyedHash
+//
+// Historically, the encoding was implicit and left to the crypto engine
+// (esrp.Crypto#H of Standard crypto pads all values to the length of the
+// first one). Explicit encoding is applied by engine uniformly: every value
+// is encoded, encoded values are concatenated and the crypto engine receives
+// a single value, so it doesn't apply any padding of its own.
+type Encoding int
+
+// Encodings
+//
+// Implicit - values are passed to crypto as is (default)
+// Raw      - minimal big-endian bytes, without leading zeros
+// Padded   - big-endian bytes left-padded with zeros to byte length of N
+// Hex      - lowercase hex ASCII of minimal big-endian bytes
+const (
+	Implicit Encoding = iota
+	Raw
+	Padded
+	Hex
+)
+
+// NewWithEncoding function Constructor
+//
+// Params:
+// - crypto   {esrp.Crypto} crypto engine
+// - group    {esrp.Group}  group params
+// - encoding {Encoding}    encoding of values inside hashes
+//
+// Response:
+// - {Engine}
+func NewWithEncoding(crypto c.Crypto, group g.Group, encoding Encoding) Engine {
+	return NewWithParams(crypto, group, encoding, DefaultMultiplier)
+}
+
+// Encoding function: encoding of values inside hashes
+//
+// Response:
+// - {Encoding}
+func (e Engine) Encoding() Encoding {
+	return e.encoding
+}
+
+// hash function: H over encoded values
+//
+// Params:
+// - values {[]esrp.Value} values to be hashed
+//
+// Response:
+// - {esrp.Value}
+func (e Engine) hash(values ...v.Value) v.Value {
+	if e.encoding == Implicit {
+		return e.crypto.H(values...)
+	}
+
+	return e.crypto.H(e.encode(values...))
+}
+
+// keyedHash function: KeyedHash over encoded values
+//
+// Params:
+// - key    {esrp.Value}
+// - values {[]esrp.Value} message values
+//
+// Response:
+// - {esrp.Value}
+func (e Engine) keyedHash(key v.Value, values ...v.Value) v.Value {
+	return e.crypto.KeyedHash(key, e.encode(values...))
+}
+
+// encode function: encode and concatenate values
+//
+// Params:
+// - values {[]esrp.Value}
+//
+// Response:
+// - {esrp.Value}
+func (e Engine) encode(values ...v.Value) v.Value {
+	return e.encodeAs(e.encoding, values...)
+}
+
+// encodeAs function: encode and concatenate values with given encoding
+//
+// RFC-compliant engines (GnuTLS, RFC5054, SrpRb) use Padded one for u
+// regardless of engine encoding.
+//
+// Params:
+// - encoding {Encoding}
+// - values   {[]esrp.Value}
+//
+// Response:
+// - {esrp.Value}
+func (e Engine) encodeAs(encoding Encoding, values ...v.Value) v.Value {
+	length := len(e.N.Bytes())
+	buff := make([]byte, 0, len(values)*2*length)
+
+	for _, value := range values {
+		bytes := value.Bytes()
+
+		if encoding != Implicit {
+			bytes = minimal(bytes)
+		}
+
+		switch encoding {
+		case Padded:
+			if len(bytes) < length {
+				buff = append(buff, make([]byte, length-len(bytes))...)
+			}
+
+			buff = append(buff, bytes...)
+		case Hex:
+			start := len(buff)
+			buff = append(buff, make([]byte, hex.EncodedLen(len(bytes)))...)
+			hex.Encode(buff[start:], bytes)
+		default:
+			buff = append(buff, bytes...)
+		}
+	}
+
+	return v.New(buff)
+}
+
+// minimal function: bytes without leading zeros
+//
+// Params:
+// - bytes {[]byte}
+//
+// Response:
+// - {[]byte}
+func minimal(bytes []byte) []byte {
+	for len(bytes) > 0 && bytes[0] == 0 {
+		bytes = bytes[1:]
+	}
+
+	return bytes
+}
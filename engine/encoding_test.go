@@ -0,0 +1,62 @@
+package engine_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestEncodingK(t *testing.T) {
+	sha1 := c.NewStandard(hash.SHA1)
+	cases := map[e.Encoding]string{
+		e.Implicit: vectors["k"],
+		e.Padded:   vectors["k"],
+		e.Raw:      "fe4e7e548761718eef3f3eb73454916dd4700f81",
+		e.Hex:      "156831cd39bfa246e6ca1f309e35f95961ecfc50",
+	}
+
+	for encoding, expected := range cases {
+		engine := e.NewWithEncoding(sha1, grp, encoding)
+
+		if engine.Encoding() != encoding {
+			t.Error("encoding should be equal")
+		}
+
+		if engine.K().Hex() != expected {
+			t.Errorf("k should be equal for encoding %d", encoding)
+		}
+	}
+}
+
+func TestEncodingPaddedU(t *testing.T) {
+	engine := e.NewWithEncoding(c.NewStandard(hash.SHA1), grp, e.Padded)
+	subj := engine.CalcU(value.New(vectors["A"]), value.New(vectors["B"]))
+
+	if subj.Hex() != vectors["u"] {
+		t.Error("u should be equal to RFC5054 one")
+	}
+}
+
+func TestEncodingStandardCalcM(t *testing.T) {
+	sha256 := c.NewStandard(hash.SHA256)
+	implicit := e.Standard{Engine: e.New(sha256, grp)}
+	raw := e.Standard{Engine: e.NewWithEncoding(sha256, grp, e.Raw)}
+
+	kk := value.New("0c486f95")
+	aa := value.New(vectors["A"])
+	bb := value.New(vectors["B"])
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+
+	expected := sha256.KeyedHash(kk, value.New(aa.Hex()+salt.Hex()+bb.Hex()))
+
+	if raw.CalcM(kk, aa, bb, kk, salt, "").Hex() != expected.Hex() {
+		t.Error("M should be HMAC over concatenation")
+	}
+
+	if implicit.CalcM(kk, aa, bb, kk, salt, "").Hex() == expected.Hex() {
+		t.Error("implicit M should keep sum behavior")
+	}
+}
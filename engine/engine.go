@@ -65,12 +65,32 @@ type Engine struct {
 	// Current crypto engine
 	//
 	// Response: {esrp.Crypto}
-	crypto c.Crypto
-	N      v.Value
-	G      v.Value
-	k      v.Value
+	crypto      c.Crypto
+	N           v.Value
+	G           v.Value
+	k           v.Value
+	hasK        bool
+	encoding    Encoding
+	multiplier  Multiplier
+	identity    Identity
+	proof       Proof
+	privateKey  PrivateKey
+	usernameInM bool
+	modExpImpl  ModExp
 }
 
+// Multiplier type: formula of multiplier parameter (k)
+//
+// Default - k = H(N | g), values are encoded as engine does (see Encoding)
+// Padded  - k = H(N | PAD(g)), as RFC5054 requires, regardless of encoding
+type Multiplier int
+
+// Multipliers
+const (
+	DefaultMultiplier Multiplier = iota
+	PaddedMultiplier
+)
+
 // Interface (engine.Interface) is an interface for crypto engine
 type Interface interface {
 
@@ -191,29 +211,74 @@ type Interface interface {
 // - crypto {esrp.Crypto} crypto engine
 // - group  {esrp.Group} group params
 func New(crypto c.Crypto, group g.Group) Engine {
-	return Engine{
-		crypto: crypto,
-		N:      group.N,
-		G:      group.G,
-		k:      crypto.H(group.N, group.G),
+	return NewWithParams(crypto, group, Implicit, DefaultMultiplier)
+}
+
+// NewWithParams function Constructor
+//
+// Params:
+// - crypto     {esrp.Crypto} crypto engine
+// - group      {esrp.Group}  group params
+// - encoding   {Encoding}    encoding of values inside hashes
+// - multiplier {Multiplier}  formula of multiplier parameter (k)
+func NewWithParams(crypto c.Crypto, group g.Group, encoding Encoding, multiplier Multiplier) Engine {
+	engine := Engine{
+		crypto:     crypto,
+		N:          group.N,
+		G:          group.G,
+		encoding:   encoding,
+		multiplier: multiplier,
 	}
+
+	engine.k = engine.calcK()
+	engine.hasK = true
+
+	return engine
 }
 
 // K function: Multiplier parameter (k)
 //
-// k = H(N | g)
+//   k = H(N | g)
 //   k = H(N | PAD(g)) - RFC5054
 //
 // Response:
 // - {ESRP::Value} multiplier parameter (k)
 func (e Engine) K() v.Value {
-	if e.k.Hex() == "" {
-		return e.crypto.H(e.N, e.G)
+	if !e.hasK {
+		return e.calcK()
 	}
 
 	return e.k
 }
 
+// PrimeLength function: length of group prime (N) in bits
+//
+// Response:
+// - {int}
+func (e Engine) PrimeLength() int {
+	return e.N.Int().BitLen()
+}
+
+// Multiplier function: formula of multiplier parameter (k)
+//
+// Response:
+// - {Multiplier}
+func (e Engine) Multiplier() Multiplier {
+	return e.multiplier
+}
+
+// calcK function: compute multiplier parameter (k)
+//
+// Response:
+// - {ESRP::Value} multiplier parameter (k)
+func (e Engine) calcK() v.Value {
+	if e.multiplier == PaddedMultiplier {
+		return e.crypto.H(v.New(append(e.N.Bytes(), e.Pad(e.G).Bytes()...)))
+	}
+
+	return e.hash(e.N, e.G)
+}
+
 // CalcV function: Calculate password verifier (v)
 //
 //   v = g^x
@@ -261,6 +326,21 @@ func (e Engine) CalcB(b, val v.Value) v.Value {
 	return v.New(new(big.Int).Mod(res, e.N.Int()))
 }
 
+// IsValidPublic function: check public ephemeral value (A or B)
+//
+// The host MUST abort the authentication if A mod N == 0,
+// the client MUST abort the authentication if B mod N == 0
+//
+// Params:
+// - value {esrp.Value} public ephemeral value (A or B)
+//
+// Response:
+// - {bool} false if authentication must be aborted
+func (e Engine) IsValidPublic(value v.Value) bool {
+	val := new(big.Int).SetBytes(value.Bytes())
+	return val.Mod(val, e.N.Int()).Sign() != 0
+}
+
 // CalcU function: random scrambling parameter (u)
 //
 //   u = H(A | B)
@@ -273,7 +353,7 @@ func (e Engine) CalcB(b, val v.Value) v.Value {
 // Response:
 // - {esrp.Value} random scrambling parameter (u)
 func (e Engine) CalcU(aa, bb v.Value) v.Value {
-	return e.crypto.H(aa, bb)
+	return e.hash(aa, bb)
 }
 
 // CalcClientS function: Calcalate client session key (S)
@@ -291,6 +371,7 @@ func (e Engine) CalcU(aa, bb v.Value) v.Value {
 func (e Engine) CalcClientS(bb, a, x, u v.Value) v.Value {
 	mul := new(big.Int).Mul(e.k.Int(), e.modExp(e.G, x).Int())
 	left := new(big.Int).Sub(bb.Int(), mul)
+	left.Mod(left, e.N.Int()) // value keeps no sign, so the base must be non-negative
 	right := new(big.Int).Add(a.Int(), new(big.Int).Mul(u.Int(), x.Int()))
 
 	return e.modExp(v.New(left), v.New(right))
@@ -327,13 +408,58 @@ func (e Engine) CalcServerS(aa, b, val, u v.Value) v.Value {
 // Response:
 // - {ESRP::Value} private session key (K)
 func (e Engine) CalcK(ss v.Value) v.Value {
-	return e.crypto.H(ss)
+	return e.hash(ss)
+}
+
+// Pad function: PAD() primitive, left-pad value with zeros up to byte length of N
+//
+//   PAD(0x0102) = 0x000...0102
+//
+// RFC-compliant engines (GnuTLS, RFC5054) use it for g in k and pad A, B in u
+// the same way (Padded encoding), so hashed values have fixed length defined
+// by the group. A and B themselves are transmitted as is. Values which are
+// already long enough are returned as is.
+//
+// Params:
+// - value {esrp.Value}
+//
+// Response:
+// - {esrp.Value}
+func (e Engine) Pad(value v.Value) v.Value {
+	return c.LeftPad(value, len(e.N.Bytes()))
+}
+
+// AppendPad function: append value padded to byte length of N
+//
+// Allocation-free variant of PAD() for hot paths: doesn't allocate
+// if dst has enough capacity, e.g. make([]byte, 0, 2*len(N)) for PAD(A) | PAD(B).
+//
+// Only assembly of hash inputs is covered. Calc* functions still allocate
+// their results: Crypto returns new values and math/big allocates while
+// exponentiating, so there are no buffer-writing variants of them.
+//
+// Params:
+// - dst   {[]byte}
+// - value {esrp.Value}
+//
+// Response:
+// - {[]byte} extended dst
+func (e Engine) AppendPad(dst []byte, value v.Value) []byte {
+	return value.AppendPadded(dst, len(e.N.Bytes()))
+}
+
+// Crypto function: current crypto engine
+//
+// Response:
+// - {esrp.Crypto}
+func (e Engine) Crypto() c.Crypto {
+	return e.crypto
 }
 
 // modExp function: modular exponentation
 //
 // As mentioned above, this method reflects '^' operator in SRP
-// which interprets as 'a^b%N' ('a EXP b MOD N')
+// which interprets as 'a^b%N' ('a EXP b MOD N'), see ModExp
 //
 // Params:
 // - a {esrp.Value}
@@ -342,5 +468,9 @@ func (e Engine) CalcK(ss v.Value) v.Value {
 // Response:
 // - {esrp.Value}
 func (e Engine) modExp(a v.Value, b v.Value) v.Value {
+	if e.modExpImpl != nil {
+		return v.New(e.modExpImpl.Exp(a.Int(), b.Int(), e.N.Int()))
+	}
+
 	return v.New(new(big.Int).Exp(a.Int(), b.Int(), e.N.Int()))
 }
@@ -3,6 +3,7 @@ package engine_test
 import (
 	hash "crypto"
 	"fmt"
+	"math/big"
 	"testing"
 
 	c "github.com/nsheremet/esrp/crypto"
@@ -39,7 +40,7 @@ func TestEngineCalcV(t *testing.T) {
 }
 
 func TestEngineCalcA(t *testing.T) {
-	subj := instance.CalcV(value.New(vectors["v"]))
+	subj := instance.CalcA(value.New(vectors["a"]))
 
 	if subj.Hex() != vectors["A"] {
 		t.Error("hex should be equal")
@@ -77,6 +78,26 @@ func TestEngineCalcClientS(t *testing.T) {
 	}
 }
 
+func TestEngineCalcClientSNegativeBase(t *testing.T) {
+	engine := e.New(c.NewStandard(hash.SHA256), grp)
+	a := value.New(vectors["a"])
+	b := value.New(vectors["b"])
+	x := value.New(vectors["x"])
+
+	val := engine.CalcV(x)
+	aa := engine.CalcA(a)
+	bb := engine.CalcB(b, val)
+	u := engine.CalcU(aa, bb)
+
+	if bb.Int().Cmp(new(big.Int).Mul(engine.K().Int(), val.Int())) >= 0 {
+		t.Fatal("B should be less than kv, so that B - kv is negative")
+	}
+
+	if engine.CalcClientS(bb, a, x, u).Hex() != engine.CalcServerS(aa, b, val, u).Hex() {
+		t.Error("client S should be equal to server S when B - kv is negative")
+	}
+}
+
 func TestEngineCalcServerS(t *testing.T) {
 	crypto = c.NewStandard(hash.SHA1)
 	instance = e.New(crypto, grp)
@@ -92,3 +113,93 @@ func TestEngineCalcServerS(t *testing.T) {
 		t.Error("ServerS should be equal to hex")
 	}
 }
+
+func TestEngineMultiplier(t *testing.T) {
+	sha1 := c.NewStandard(hash.SHA1)
+	padded := e.NewWithParams(sha1, grp, e.Raw, e.PaddedMultiplier)
+	unpadded := e.NewWithParams(sha1, grp, e.Raw, e.DefaultMultiplier)
+
+	if padded.Multiplier() != e.PaddedMultiplier {
+		t.Error("multiplier should be equal")
+	}
+
+	if padded.K().Hex() != vectors["k"] {
+		t.Error("padded k should be equal to RFC5054 one")
+	}
+
+	if unpadded.K().Hex() == vectors["k"] {
+		t.Error("unpadded k should not be padded")
+	}
+}
+
+func TestEngineLargeGenerator(t *testing.T) {
+	generator := "0123456789abcdef0123456789abcdef"
+	engine := e.NewWithParams(crypto, group.NewWithGenerator(1024, generator, vectors["N"]), e.Implicit, e.PaddedMultiplier)
+	padded := fmt.Sprintf("%0256s", generator)
+
+	if engine.K().Hex() != crypto.H(value.New(vectors["N"]+padded)).Hex() {
+		t.Error("k should be calculated with PAD(g)")
+	}
+
+	a := value.New(vectors["a"])
+	b := value.New(vectors["b"])
+	x := value.New(vectors["x"])
+	val := engine.CalcV(x)
+	aa := engine.CalcA(a)
+	bb := engine.CalcB(b, val)
+	u := engine.CalcU(aa, bb)
+
+	if engine.CalcClientS(bb, a, x, u).Hex() != engine.CalcServerS(aa, b, val, u).Hex() {
+		t.Error("client and server S should be equal")
+	}
+}
+
+func TestEngineAppendPad(t *testing.T) {
+	engine := e.New(crypto, grp)
+	aa := value.New("0102")
+	dst := make([]byte, 0, 2*len(grp.N.Bytes()))
+
+	dst = engine.AppendPad(dst, aa)
+
+	if value.New(dst).Hex() != fmt.Sprintf("%0256s", "0102") {
+		t.Error("value should be padded to length of N")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		dst = engine.AppendPad(engine.AppendPad(dst[:0], aa), aa)
+	})
+
+	if allocs != 0 {
+		t.Error("append should not allocate")
+	}
+}
+
+func TestEnginePad(t *testing.T) {
+	engine := e.New(crypto, grp)
+
+	if engine.Pad(value.New("0102")).Hex() != fmt.Sprintf("%0256s", "0102") {
+		t.Error("value should be padded to length of N")
+	}
+
+	if engine.Pad(grp.N).Hex() != grp.N.Hex() {
+		t.Error("long enough value should be kept as is")
+	}
+}
+
+func TestEngineIsValidPublic(t *testing.T) {
+	engine := e.New(crypto, grp)
+
+	if engine.IsValidPublic(value.New("00")) || engine.IsValidPublic(grp.N) {
+		t.Error("zero mod N should be invalid")
+	}
+
+	if !engine.IsValidPublic(value.New(vectors["A"])) {
+		t.Error("A should be valid")
+	}
+}
+
+func TestEnginePrimeLength(t *testing.T) {
+	if e.New(crypto, grp).PrimeLength() != 1024 {
+		t.Error("prime length should be equal")
+	}
+}
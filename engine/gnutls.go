@@ -0,0 +1,64 @@
+package engine
+
+import (
+	hash "crypto"
+
+	c "github.com/nsheremet/esrp/crypto"
+	g "github.com/nsheremet/esrp/group"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// GnuTLS is GnuTLS / libgcrypt TLS-SRP compatible engine
+//
+// Matches GnuTLS SRP key exchange, so verifiers computed here may be used
+// as a backend for services terminating TLS-SRP with GnuTLS.
+// All padding is done up to byte length of N:
+//
+//   k = H(N | PAD(g))
+//   x = H(s | H(I | ":" | p))
+//   u = H(PAD(A) | PAD(B))
+//
+// x is calculated by Engine#CalcX with RFC2945Key private key.
+// TLS-SRP has no M and M2, TLS Finished messages are used instead,
+// and premaster secret (S) is used as is, without leading zero bytes.
+// So, GnuTLS engine provides neither CalcM nor CalcM2.
+type GnuTLS struct {
+	Engine
+}
+
+// NewGnuTLS function Constructor
+//
+// Params:
+// - group {esrp.Group} group params
+//
+// Response:
+// - {GnuTLS}
+func NewGnuTLS(group g.Group) GnuTLS {
+	return GnuTLS{Engine: NewWithParams(c.NewStandard(hash.SHA1), group, Implicit, PaddedMultiplier)}
+}
+
+// WithIdentity function: copy of engine with username formatting hook
+//
+// Params:
+// - identity {Identity} formatting hook, nil keeps username as is
+//
+// Response:
+// - {GnuTLS}
+func (e GnuTLS) WithIdentity(identity Identity) GnuTLS {
+	e.Engine = e.Engine.WithIdentity(identity)
+	return e
+}
+
+// CalcU function: random scrambling parameter (u)
+//
+//   u = H(PAD(A) | PAD(B))
+//
+// Params:
+// - aa {v.Value} client ephemeral value (A)
+// - bb {v.Value} server ephemeral value (B)
+//
+// Response:
+// - {v.Value} random scrambling parameter (u)
+func (e GnuTLS) CalcU(aa, bb v.Value) v.Value {
+	return e.crypto.H(e.encodeAs(Padded, aa, bb))
+}
@@ -0,0 +1,46 @@
+package engine_test
+
+import (
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/value"
+)
+
+var gnuTLS = e.NewGnuTLS(group.New(1024, 2, vectors["N"]))
+
+func TestGnuTLSCalcX(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	subj := gnuTLS.CalcX("password123", salt, "alice")
+
+	if subj.Hex() != vectors["x"] {
+		t.Error("x should be equal")
+	}
+}
+
+func TestGnuTLSCalcU(t *testing.T) {
+	subj := gnuTLS.CalcU(value.New(vectors["A"]), value.New(vectors["B"]))
+
+	if subj.Hex() != vectors["u"] {
+		t.Error("u should be equal")
+	}
+}
+
+func TestGnuTLSCalcServerS(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	val := gnuTLS.CalcV(gnuTLS.CalcX("password123", salt, "alice"))
+	aa := gnuTLS.CalcA(value.New(vectors["a"]))
+	b := value.New(vectors["b"])
+	bb := gnuTLS.CalcB(b, val)
+
+	if bb.Hex() != vectors["B"] {
+		t.Error("B should be equal")
+	}
+
+	subj := gnuTLS.CalcServerS(aa, b, val, gnuTLS.CalcU(aa, bb))
+
+	if subj.Hex() != vectors["S"] {
+		t.Error("S should be equal")
+	}
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"strings"
+)
+
+// Identity type: username (I) formatting hook
+//
+// Engines which hash username apply it before hashing, so every party
+// qualifies identity with realm/domain the same way, e.g. "CORP\alice"
+// and "alice@corp" give the same verifier.
+type Identity func(username string) string
+
+// DownLevel function: identity in "REALM\username" form
+//
+// Username already qualified in any form is requalified with given realm.
+//
+// Params:
+// - realm {string} realm (domain) name, e.g. "CORP"
+//
+// Response:
+// - {Identity}
+func DownLevel(realm string) Identity {
+	return func(username string) string {
+		return realm + `\` + bare(username)
+	}
+}
+
+// Principal function: identity in "username@domain" form
+//
+// Username already qualified in any form is requalified with given domain.
+//
+// Params:
+// - domain {string} domain (realm) name, e.g. "corp"
+//
+// Response:
+// - {Identity}
+func Principal(domain string) Identity {
+	return func(username string) string {
+		return bare(username) + "@" + domain
+	}
+}
+
+// WithIdentity function: copy of engine with username formatting hook
+//
+// Params:
+// - identity {Identity} formatting hook, nil keeps username as is
+//
+// Response:
+// - {Engine}
+func (e Engine) WithIdentity(identity Identity) Engine {
+	e.identity = identity
+	return e
+}
+
+// identify function: username formatted with identity hook
+//
+// Params:
+// - username {string} plain-text username in UTF8 string
+//
+// Response:
+// - {string}
+func (e Engine) identify(username string) string {
+	if e.identity == nil {
+		return username
+	}
+
+	return e.identity(username)
+}
+
+// bare function: username without "REALM\" prefix and "@domain" suffix
+func bare(username string) string {
+	if i := strings.LastIndex(username, `\`); i >= 0 {
+		username = username[i+1:]
+	}
+
+	if i := strings.LastIndex(username, "@"); i >= 0 {
+		username = username[:i]
+	}
+
+	return username
+}
@@ -0,0 +1,49 @@
+package engine_test
+
+import (
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestDownLevel(t *testing.T) {
+	identity := e.DownLevel("CORP")
+
+	for _, username := range []string{"alice", `CORP\alice`, `OTHER\alice`, "alice@corp"} {
+		if identity(username) != `CORP\alice` {
+			t.Errorf("%s should be qualified as CORP\\alice", username)
+		}
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	identity := e.Principal("corp")
+
+	for _, username := range []string{"alice", `CORP\alice`, "alice@other", "alice@corp"} {
+		if identity(username) != "alice@corp" {
+			t.Errorf("%s should be qualified as alice@corp", username)
+		}
+	}
+}
+
+func TestGnuTLSCalcXWithIdentity(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	engine := gnuTLS.WithIdentity(e.Principal("corp"))
+
+	if engine.CalcX("password123", salt, `CORP\alice`).Hex() != gnuTLS.CalcX("password123", salt, "alice@corp").Hex() {
+		t.Error("x should be equal for equally qualified identities")
+	}
+
+	if engine.CalcX("password123", salt, "alice").Hex() == gnuTLS.CalcX("password123", salt, "alice").Hex() {
+		t.Error("x should depend on identity hook")
+	}
+}
+
+func TestGnuTLSCalcXWithNilIdentity(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+
+	if gnuTLS.WithIdentity(nil).CalcX("password123", salt, "alice").Hex() != vectors["x"] {
+		t.Error("x should be equal")
+	}
+}
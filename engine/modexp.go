@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"math/big"
+)
+
+// ModExp interface: modular exponentiation backend
+//
+// Every '^' operation of the engine (v, A, B and S) goes through it, so
+// optimized implementations (e.g. Montgomery kernels in assembly) may be
+// plugged in with WithModExp. Implementations must not modify arguments.
+type ModExp interface {
+	// Interface function: modular exponentiation
+	//
+	//   base^exponent % modulus
+	//
+	// Params:
+	// - base     {*big.Int}
+	// - exponent {*big.Int}
+	// - modulus  {*big.Int} odd large prime (N)
+	//
+	// Response:
+	// - {*big.Int}
+	Exp(base, exponent, modulus *big.Int) *big.Int
+}
+
+// BigModExp struct: math/big modular exponentiation (default)
+type BigModExp struct{}
+
+// Exp function: modular exponentiation with math/big
+//
+// Params:
+// - base     {*big.Int}
+// - exponent {*big.Int}
+// - modulus  {*big.Int}
+//
+// Response:
+// - {*big.Int}
+func (BigModExp) Exp(base, exponent, modulus *big.Int) *big.Int {
+	return new(big.Int).Exp(base, exponent, modulus)
+}
+
+// WithModExp function: copy of engine with modular exponentiation backend
+//
+// Params:
+// - modExp {ModExp} backend, nil for math/big
+//
+// Response:
+// - {Engine}
+func (e Engine) WithModExp(modExp ModExp) Engine {
+	e.modExpImpl = modExp
+	return e
+}
+
+// ModExp function: modular exponentiation backend, nil for math/big
+//
+// Response:
+// - {ModExp}
+func (e Engine) ModExp() ModExp {
+	return e.modExpImpl
+}
@@ -0,0 +1,45 @@
+package engine_test
+
+import (
+	"math/big"
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/esrptest"
+	"github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/value"
+)
+
+// countingModExp struct: math/big backend counting calls
+type countingModExp struct {
+	calls *int
+}
+
+func (c countingModExp) Exp(base, exponent, modulus *big.Int) *big.Int {
+	*c.calls++
+	return e.BigModExp{}.Exp(base, exponent, modulus)
+}
+
+func TestEngineWithModExp(t *testing.T) {
+	calls := 0
+	engine := e.New(crypto, grp).WithModExp(countingModExp{&calls})
+
+	if engine.CalcV(value.New(vectors["x"])).Hex() != vectors["v"] {
+		t.Error("v should be equal")
+	}
+
+	if calls != 1 {
+		t.Error("backend should be used")
+	}
+}
+
+func BenchmarkModExp(b *testing.B) {
+	var groups []group.Group
+
+	for _, bits := range []int{2048, 3072, 4096} {
+		grp, _ := group.RFC5054(bits)
+		groups = append(groups, grp)
+	}
+
+	esrptest.BenchmarkModExp(b, e.BigModExp{}, groups...)
+}
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"math/big"
+
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Ephemeral struct: pre-generated server ephemeral values
+//
+// Provides:
+// Secret - secret server ephemeral value (b)
+// Public - g^b, the expensive part of public server ephemeral value (B)
+type Ephemeral struct {
+	Secret v.Value
+	Public v.Value
+}
+
+// Pool struct: pool of pre-generated server ephemeral values
+//
+// Computing g^b for large groups is the most expensive part of issuing
+// a challenge. The pool allows to pre-generate ephemerals during idle time
+// (see Fill) and consume them on challenge (see Get), smoothing CPU spikes
+// during login storms. Pool is safe for concurrent use; every ephemeral
+// is handed out at most once.
+type Pool struct {
+	engine      Engine
+	bytesLength int
+	ephemerals  chan Ephemeral
+}
+
+// NewPool function Constructor
+//
+// Params:
+// - engine      {Engine} engine to compute ephemerals with
+// - size        {int}    pool capacity
+// - bytesLength {int}    length of secret ephemeral value (b) in bytes
+//
+// Response:
+// - {*Pool}
+func NewPool(engine Engine, size, bytesLength int) *Pool {
+	return &Pool{
+		engine:      engine,
+		bytesLength: bytesLength,
+		ephemerals:  make(chan Ephemeral, size),
+	}
+}
+
+// Fill function: generate ephemerals until the pool is full
+//
+// Response:
+// - {int} count of generated ephemerals
+func (p *Pool) Fill() int {
+	count := 0
+
+	for len(p.ephemerals) < cap(p.ephemerals) {
+		select {
+		case p.ephemerals <- p.engine.NewEphemeral(p.bytesLength):
+			count++
+		default:
+			return count
+		}
+	}
+
+	return count
+}
+
+// Get function: take pre-generated ephemeral
+//
+// Generates a fresh one if the pool is empty.
+//
+// Response:
+// - {Ephemeral}
+func (p *Pool) Get() Ephemeral {
+	select {
+	case ephemeral := <-p.ephemerals:
+		return ephemeral
+	default:
+		return p.engine.NewEphemeral(p.bytesLength)
+	}
+}
+
+// Len function: count of pre-generated ephemerals available
+//
+// Response:
+// - {int}
+func (p *Pool) Len() int {
+	return len(p.ephemerals)
+}
+
+// NewEphemeral function: generate server ephemeral values
+//
+// Params:
+// - bytesLength {int} length of secret ephemeral value (b) in bytes
+//
+// Response:
+// - {Ephemeral}
+func (e Engine) NewEphemeral(bytesLength int) Ephemeral {
+	b := e.crypto.Random(bytesLength)
+
+	return Ephemeral{
+		Secret: b,
+		Public: e.modExp(e.G, b),
+	}
+}
+
+// CalcKV function: multiplier and password verifier product
+//
+//   kv = k * v % N
+//
+// May be cached per user along with the verifier.
+//
+// Params:
+// - val {esrp.Value} password verifier (v)
+//
+// Response:
+// - {esrp.Value}
+func (e Engine) CalcKV(val v.Value) v.Value {
+	mul := new(big.Int).Mul(e.K().Int(), val.Int())
+	return v.New(mul.Mod(mul, e.N.Int()))
+}
+
+// CalcBWithKV function: Calculate public server ephemeral value (B) from precomputed values
+//
+//   B = kv + g^b % N
+//
+// Params:
+// - kv        {esrp.Value} multiplier and password verifier product (see CalcKV)
+// - ephemeral {Ephemeral}  pre-generated server ephemeral values
+//
+// Response:
+// - {esrp.Value} public server ephemeral value (B)
+func (e Engine) CalcBWithKV(kv v.Value, ephemeral Ephemeral) v.Value {
+	res := new(big.Int).Add(kv.Int(), ephemeral.Public.Int())
+	return v.New(res.Mod(res, e.N.Int()))
+}
@@ -0,0 +1,79 @@
+package engine_test
+
+import (
+	hash "crypto"
+	"sync"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestPoolCalcBWithKV(t *testing.T) {
+	engine := e.New(c.NewStandard(hash.SHA1), grp)
+	val := engine.CalcV(value.New(vectors["x"]))
+	ephemeral := e.Ephemeral{
+		Secret: value.New(vectors["b"]),
+		Public: engine.CalcV(value.New(vectors["b"])),
+	}
+
+	subj := engine.CalcBWithKV(engine.CalcKV(val), ephemeral)
+
+	if subj.Hex() != vectors["B"] {
+		t.Error("B should be equal")
+	}
+}
+
+func TestPoolFillAndGet(t *testing.T) {
+	engine := e.New(c.NewStandard(hash.SHA1), grp)
+	pool := e.NewPool(engine, 4, 32)
+
+	if pool.Fill() != 4 || pool.Len() != 4 {
+		t.Error("pool should be filled")
+	}
+
+	if pool.Fill() != 0 {
+		t.Error("full pool should not be refilled")
+	}
+
+	ephemeral := pool.Get()
+
+	if pool.Len() != 3 {
+		t.Error("ephemeral should be taken from pool")
+	}
+
+	if engine.CalcV(ephemeral.Secret).Hex() != ephemeral.Public.Hex() {
+		t.Error("public value should be g^b")
+	}
+}
+
+func TestPoolGetUnique(t *testing.T) {
+	engine := e.New(c.NewStandard(hash.SHA1), grp)
+	pool := e.NewPool(engine, 16, 32)
+	pool.Fill()
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	seen := map[string]bool{}
+
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			secret := pool.Get().Secret.Hex()
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if seen[secret] {
+				t.Error("ephemeral should not be reused")
+			}
+
+			seen[secret] = true
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	v "github.com/nsheremet/esrp/value"
+)
+
+// PrivateKey type: formula of private key (x) used by Engine#CalcX
+//
+// RFC2945Key  - x = H(s | H(I | ":" | p)), as RFC2945 and RFC5054 define (default)
+// SeparateKey - x = H(s | H(I) | H(p)), as seen in some older commercial SDKs
+//
+// Standard engine derives x with KDF and ignores this option.
+type PrivateKey int
+
+// Private keys
+const (
+	RFC2945Key PrivateKey = iota
+	SeparateKey
+)
+
+// WithPrivateKey function: copy of engine with private key formula
+//
+// Params:
+// - key {PrivateKey} formula of private key (x)
+//
+// Response:
+// - {Engine}
+func (e Engine) WithPrivateKey(key PrivateKey) Engine {
+	e.privateKey = key
+	return e
+}
+
+// PrivateKey function: formula of private key (x)
+//
+// Response:
+// - {PrivateKey}
+func (e Engine) PrivateKey() PrivateKey {
+	return e.privateKey
+}
+
+// CalcX function: Calculate private key (x) with selected formula
+//
+//   x = H(s | H(I | ":" | p))
+//   x = H(s | H(I) | H(p))
+//
+// Values are concatenated as bytes and hashed at once, so crypto engine
+// doesn't apply padding of its own. Username is formatted with identity
+// hook (see WithIdentity).
+//
+// Params:
+// - password {string}  plain-text password in UTF8 string
+// - salt     {v.Value} random generated salt (s)
+// - username {string}  plain-text username in UTF8 string
+//
+// Returns: {v.Value} private key (x)
+func (e Engine) CalcX(password string, salt v.Value, username string) v.Value {
+	buff := append([]byte{}, salt.Bytes()...)
+
+	if e.privateKey == SeparateKey {
+		buff = append(buff, e.crypto.H(v.New([]byte(e.identify(username)))).Bytes()...)
+		buff = append(buff, e.crypto.H(v.New([]byte(password))).Bytes()...)
+	} else {
+		buff = append(buff, e.crypto.H(v.New([]byte(e.identify(username)+":"+password))).Bytes()...)
+	}
+
+	return e.crypto.H(v.New(buff))
+}
@@ -0,0 +1,29 @@
+package engine_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestEngineCalcXPrivateKeys(t *testing.T) {
+	engine := e.New(c.NewStandard(hash.SHA1), grp)
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	cases := map[e.PrivateKey]string{
+		e.RFC2945Key:  vectors["x"],
+		e.SeparateKey: "eeaa22caa83172c921c12d9a9f4d787a57c3282e",
+	}
+
+	if engine.PrivateKey() != e.RFC2945Key {
+		t.Error("RFC2945 key should be default")
+	}
+
+	for key, expected := range cases {
+		if engine.WithPrivateKey(key).CalcX("password123", salt, "alice").Hex() != expected {
+			t.Errorf("x should be equal with private key %d", key)
+		}
+	}
+}
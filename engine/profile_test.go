@@ -0,0 +1,37 @@
+package engine_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/group"
+)
+
+// benchmarkDefaultProfile function: hash-heavy part of SHA-256/2048 handshake
+func benchmarkDefaultProfile(b *testing.B, encoding e.Encoding) {
+	grp, _ := group.RFC5054(2048)
+	sha256 := c.NewStandard(hash.SHA256)
+	engine := e.Standard{Engine: e.NewWithEncoding(sha256, grp, encoding)}
+	aa := engine.CalcA(sha256.Random(32))
+	bb := engine.CalcA(sha256.Random(32))
+	salt := sha256.Random(16)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		u := engine.CalcU(aa, bb)
+		kk := engine.CalcK(u)
+		mm := engine.CalcM(kk, aa, bb, u, salt, "")
+		engine.CalcM2(kk, aa, mm, u)
+	}
+}
+
+func BenchmarkDefaultProfileImplicit(b *testing.B) {
+	benchmarkDefaultProfile(b, e.Implicit)
+}
+
+func BenchmarkDefaultProfilePadded(b *testing.B) {
+	benchmarkDefaultProfile(b, e.Padded)
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Proof type: formula of validation message (M) in Standard-style engines
+//
+// Keyed     - M = HMAC(K, A | s | B), default (see Standard#CalcM)
+// Composite - M = H(H(N) xor H(g) | H(I) | s | A | B | K), as described
+//             in SRP-6a design docs and RFC2945
+type Proof int
+
+// Proofs
+const (
+	KeyedProof Proof = iota
+	CompositeProof
+)
+
+// WithProof function: copy of engine with validation message formula
+//
+// Params:
+// - proof {Proof} formula of validation message (M)
+//
+// Response:
+// - {Engine}
+func (e Engine) WithProof(proof Proof) Engine {
+	e.proof = proof
+	return e
+}
+
+// Proof function: formula of validation message (M)
+//
+// Response:
+// - {Proof}
+func (e Engine) Proof() Proof {
+	return e.proof
+}
+
+// compositeM function: Calculate validation message (M) with composite hash
+//
+//   M = H(H(N) xor H(g) | H(I) | s | A | B | K)
+//
+// Values are concatenated as bytes and hashed at once, so crypto engine
+// doesn't apply padding of its own. g is padded to byte length of N with
+// PaddedMultiplier, as RFC5054 compatible implementations do.
+// Username is formatted with identity hook (see WithIdentity).
+//
+// Params:
+// - kk {v.Value} private session key (K)
+// - aa {v.Value} client ephemeral value (A)
+// - bb {v.Value} server ephemeral value (B)
+// - salt     {v.Value} random generated salt (s)
+// - username {string}  plain-text username in UTF8 string
+//
+// Response:
+// - {v.Value} validation message (M)
+func (e Engine) compositeM(kk, aa, bb, salt v.Value, username string) v.Value {
+	gg := e.G
+
+	if e.multiplier == PaddedMultiplier {
+		gg = e.Pad(gg)
+	}
+
+	hn := e.crypto.H(v.New(e.N.Bytes())).Bytes()
+	hg := e.crypto.H(v.New(gg.Bytes())).Bytes()
+	hi := e.crypto.H(v.New([]byte(e.identify(username)))).Bytes()
+
+	buff := make([]byte, 0, len(hn)+len(hi)+len(salt.Bytes())+len(aa.Bytes())+len(bb.Bytes())+len(kk.Bytes()))
+
+	for i := range hn {
+		buff = append(buff, hn[i]^hg[i])
+	}
+
+	buff = append(buff, hi...)
+	buff = append(buff, salt.Bytes()...)
+	buff = append(buff, aa.Bytes()...)
+	buff = append(buff, bb.Bytes()...)
+	buff = append(buff, kk.Bytes()...)
+
+	return e.crypto.H(v.New(buff))
+}
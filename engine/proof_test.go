@@ -0,0 +1,74 @@
+package engine_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestStandardCalcMCompositeProof(t *testing.T) {
+	sha1 := c.NewStandard(hash.SHA1)
+	kk := value.New("017eefa1cefc5c2e626e21598987f31e0f1b11bb")
+	aa := value.New(vectors["A"])
+	bb := value.New(vectors["B"])
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	cases := map[e.Multiplier]string{
+		e.DefaultMultiplier: "3f3bc67169ea71302599cf1b0f5d408b7b65d347",
+		e.PaddedMultiplier:  "62c71b289cb22a034b405667e1541202ce5d8e03",
+	}
+
+	for multiplier, expected := range cases {
+		engine := e.Standard{Engine: e.NewWithParams(sha1, grp, e.Implicit, multiplier)}.WithProof(e.CompositeProof)
+
+		if engine.CalcM(kk, aa, bb, kk, salt, "alice").Hex() != expected {
+			t.Errorf("M should be equal with multiplier %d", multiplier)
+		}
+	}
+}
+
+func TestStandardCalcMKeyedProof(t *testing.T) {
+	engine := e.Standard{Engine: e.New(crypto, grp)}
+	kk := value.New("017eefa1cefc5c2e626e21598987f31e0f1b11bb")
+	aa := value.New(vectors["A"])
+	bb := value.New(vectors["B"])
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+
+	if engine.Proof() != e.KeyedProof {
+		t.Error("keyed proof should be default")
+	}
+
+	if engine.WithProof(e.KeyedProof).CalcM(kk, aa, bb, kk, salt, "alice").Hex() != engine.CalcM(kk, aa, bb, kk, salt, "").Hex() {
+		t.Error("M should be equal")
+	}
+}
+
+func TestStandardCalcMWithUsernameInM(t *testing.T) {
+	sha256 := c.NewStandard(hash.SHA256)
+	raw := e.Standard{Engine: e.NewWithEncoding(sha256, grp, e.Raw)}.WithUsernameInM(true)
+	implicit := e.Standard{Engine: e.New(sha256, grp)}
+
+	kk := value.New("0c486f95")
+	aa := value.New(vectors["A"])
+	bb := value.New(vectors["B"])
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	hi := sha256.H(value.New([]byte("alice")))
+
+	expected := sha256.KeyedHash(kk, value.New(hi.Hex()+aa.Hex()+salt.Hex()+bb.Hex()))
+
+	if raw.CalcM(kk, aa, bb, kk, salt, "alice").Hex() != expected.Hex() {
+		t.Error("M should include H(I)")
+	}
+
+	with := implicit.WithUsernameInM(true)
+
+	if with.CalcM(kk, aa, bb, kk, salt, "alice").Hex() == with.CalcM(kk, aa, bb, kk, salt, "bob").Hex() {
+		t.Error("M should depend on username")
+	}
+
+	if implicit.WithUsernameInM(false).CalcM(kk, aa, bb, kk, salt, "alice").Hex() != implicit.CalcM(kk, aa, bb, kk, salt, "bob").Hex() {
+		t.Error("M should not depend on username by default")
+	}
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	hash "crypto"
+
+	c "github.com/nsheremet/esrp/crypto"
+	g "github.com/nsheremet/esrp/group"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// RFC5054 is RFC5054 (TLS-SRP) compliant engine
+//
+// Interoperates with RFC5054 clients, like OpenSSL's TLS-SRP.
+// Padding is defined by the group: g, A and B are padded up to byte length of N.
+//
+//   k = H(N | PAD(g))
+//   x = H(s | H(I | ":" | p))
+//   u = H(PAD(A) | PAD(B))
+//   K = H(S)
+//
+// x is calculated by Engine#CalcX with RFC2945Key private key.
+// RFC5054 leaves validation messages to TLS Finished, so outside of TLS
+// M and M2 are calculated as described in RFC2945:
+//
+//   M = H(H(N) xor H(PAD(g)) | H(I) | s | A | B | K)
+//   M2 = H(A | M | K)
+type RFC5054 struct {
+	Engine
+}
+
+// NewRFC5054 function Constructor
+//
+// Params:
+// - group {esrp.Group} group params
+//
+// Response:
+// - {RFC5054}
+func NewRFC5054(group g.Group) RFC5054 {
+	engine := NewWithParams(c.NewStandard(hash.SHA1), group, Implicit, PaddedMultiplier)
+	return RFC5054{Engine: engine.WithProof(CompositeProof)}
+}
+
+// WithIdentity function: copy of engine with username formatting hook
+//
+// Params:
+// - identity {Identity} formatting hook, nil keeps username as is
+//
+// Response:
+// - {RFC5054}
+func (e RFC5054) WithIdentity(identity Identity) RFC5054 {
+	e.Engine = e.Engine.WithIdentity(identity)
+	return e
+}
+
+// CalcU function: random scrambling parameter (u)
+//
+//   u = H(PAD(A) | PAD(B))
+//
+// Params:
+// - aa {v.Value} client ephemeral value (A)
+// - bb {v.Value} server ephemeral value (B)
+//
+// Response:
+// - {v.Value} random scrambling parameter (u)
+func (e RFC5054) CalcU(aa, bb v.Value) v.Value {
+	return e.crypto.H(e.encodeAs(Padded, aa, bb))
+}
+
+// CalcM function: Calculate validation message (M)
+//
+//   M = H(H(N) xor H(PAD(g)) | H(I) | s | A | B | K)
+//
+// Params:
+// - kk {v.Value} private session key (K)
+// - aa {v.Value} client ephemeral value (A)
+// - bb {v.Value} server ephemeral value (B)
+// - ss {v.Value} premaster secret (S) (not used here)
+// - salt     {v.Value} random generated salt (s)
+// - username {string} plain-text username in UTF8 string
+//
+// Returns: {v.Value} validation message (M)
+func (e RFC5054) CalcM(kk, aa, bb, ss, salt v.Value, username string) v.Value {
+	return e.compositeM(kk, aa, bb, salt, username)
+}
+
+// CalcM2 function: Calculate response validation message (H_AMK)
+//
+//   M2 = H(A | M | K)
+//
+// Params:
+// - kk {v.Value} private session key (K)
+// - aa {v.Value} client ephemeral value (A)
+// - mm {v.Value} validation message (M)
+// - ss {v.Value} premaster secret (S) (not used here)
+//
+// Returns: {v.Value}
+func (e RFC5054) CalcM2(kk, aa, mm, ss v.Value) v.Value {
+	buff := make([]byte, 0, len(aa.Bytes())+len(mm.Bytes())+len(kk.Bytes()))
+	buff = append(buff, aa.Bytes()...)
+	buff = append(buff, mm.Bytes()...)
+	buff = append(buff, kk.Bytes()...)
+
+	return e.crypto.H(v.New(buff))
+}
@@ -0,0 +1,72 @@
+package engine_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/value"
+)
+
+var rfc5054 = e.NewRFC5054(group.New(1024, 2, vectors["N"]))
+
+func TestRFC5054K(t *testing.T) {
+	if rfc5054.K().Hex() != vectors["k"] {
+		t.Error("k should be equal")
+	}
+}
+
+func TestRFC5054CalcX(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	subj := rfc5054.CalcX("password123", salt, "alice")
+
+	if subj.Hex() != vectors["x"] {
+		t.Error("x should be equal")
+	}
+}
+
+func TestRFC5054CalcU(t *testing.T) {
+	subj := rfc5054.CalcU(value.New(vectors["A"]), value.New(vectors["B"]))
+
+	if subj.Hex() != vectors["u"] {
+		t.Error("u should be equal")
+	}
+}
+
+func TestRFC5054CalcServerS(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	val := rfc5054.CalcV(rfc5054.CalcX("password123", salt, "alice"))
+	aa := rfc5054.CalcA(value.New(vectors["a"]))
+	b := value.New(vectors["b"])
+	bb := rfc5054.CalcB(b, val)
+
+	if val.Hex() != vectors["v"] || bb.Hex() != vectors["B"] {
+		t.Error("v and B should be equal")
+	}
+
+	subj := rfc5054.CalcServerS(aa, b, val, rfc5054.CalcU(aa, bb))
+
+	if subj.Hex() != vectors["S"] {
+		t.Error("S should be equal")
+	}
+}
+
+func TestRFC5054CalcM(t *testing.T) {
+	kk := value.New("017eefa1cefc5c2e626e21598987f31e0f1b11bb")
+	aa := value.New(vectors["A"])
+	bb := value.New(vectors["B"])
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	mm := rfc5054.CalcM(kk, aa, bb, kk, salt, "alice")
+
+	if mm.Hex() != "62c71b289cb22a034b405667e1541202ce5d8e03" {
+		t.Error("M should be equal")
+	}
+
+	expected := c.NewStandard(hash.SHA1).H(value.New(aa.Hex() + mm.Hex() + kk.Hex()))
+
+	if rfc5054.CalcM2(kk, aa, mm, kk).Hex() != expected.Hex() {
+		t.Error("M2 should be equal")
+	}
+}
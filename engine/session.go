@@ -0,0 +1,51 @@
+package engine
+
+import (
+	c "github.com/nsheremet/esrp/crypto"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Session interface: engine driving handshake of esrp.Client and esrp.Server
+//
+// Engines embedding Engine satisfy it once they implement Interface
+// (see RFC5054, SrpRb). Standard and AccountKey take salt as hex
+// string in CalcX, so they are adapted with their Session function.
+type Session interface {
+	Conformable
+	Crypto() c.Crypto
+	PrimeLength() int
+}
+
+// hexSaltSession struct: Session of engine calculating x from hex salt
+type hexSaltSession struct {
+	Standard
+	calcX func(password, salt string) v.Value
+}
+
+// CalcX function: Calculate private key (x) with adapted engine
+//
+// Params:
+// - password {string}  plain-text password in UTF8 string
+// - salt     {v.Value} random generated salt (s)
+// - username {string}  plain-text username in UTF8 string (not used here)
+//
+// Returns: {v.Value} private key (x)
+func (s hexSaltSession) CalcX(password string, salt v.Value, username string) v.Value {
+	return s.calcX(password, salt.Hex())
+}
+
+// Session function: engine as handshake Session
+//
+// Response:
+// - {Session}
+func (e Standard) Session() Session {
+	return hexSaltSession{Standard: e, calcX: e.CalcX}
+}
+
+// Session function: engine as handshake Session, x is mixed with account key
+//
+// Response:
+// - {Session}
+func (e AccountKey) Session() Session {
+	return hexSaltSession{Standard: e.Standard, calcX: e.CalcX}
+}
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	hash "crypto"
+	"strings"
+
+	c "github.com/nsheremet/esrp/crypto"
+	g "github.com/nsheremet/esrp/group"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// SrpRb is srp-rb (RubyGems "srp") compatible engine
+//
+// srp-rb hashes hex strings rather than byte arrays: values are converted
+// to hex without leading zeros ('%x'), concatenated and decoded back to bytes
+// before hashing with SHA1. Padded values are left-padded with zeros up to
+// the hex length of N. Hex of salt, H(I | ":" | p) and padded values has no
+// leading zeros to drop, so x and u are equal to ones of Engine (RFC2945Key
+// private key, see Engine#CalcX).
+//
+//   k = H(PAD(N) | PAD(g))
+//   x = H(s | H(I | ":" | p))
+//   u = H(PAD(A) | PAD(B))
+//   K = H(S)
+//   M = H(A | B | K)
+//   M2 = H(A | M | K)
+type SrpRb struct {
+	Engine
+}
+
+// NewSrpRb function Constructor
+//
+// Params:
+// - group {esrp.Group} group params
+//
+// Response:
+// - {SrpRb}
+func NewSrpRb(group g.Group) SrpRb {
+	return SrpRb{Engine: NewWithParams(c.NewStandard(hash.SHA1), group, Implicit, PaddedMultiplier)}
+}
+
+// WithIdentity function: copy of engine with username formatting hook
+//
+// Params:
+// - identity {Identity} formatting hook, nil keeps username as is
+//
+// Response:
+// - {SrpRb}
+func (e SrpRb) WithIdentity(identity Identity) SrpRb {
+	e.Engine = e.Engine.WithIdentity(identity)
+	return e
+}
+
+// CalcU function: random scrambling parameter (u)
+//
+//   u = H(PAD(A) | PAD(B))
+//
+// Params:
+// - aa {v.Value} client ephemeral value (A)
+// - bb {v.Value} server ephemeral value (B)
+//
+// Response:
+// - {v.Value} random scrambling parameter (u)
+func (e SrpRb) CalcU(aa, bb v.Value) v.Value {
+	return e.crypto.H(e.encodeAs(Padded, aa, bb))
+}
+
+// CalcK function: Calculate private session key (K)
+//
+//   K = H(S)
+//
+// Params:
+// - ss {v.Value} premaster secret (S)
+//
+// Response:
+// - {v.Value} private session key (K)
+func (e SrpRb) CalcK(ss v.Value) v.Value {
+	return e.hash(short(ss))
+}
+
+// CalcM function: Calculate validation message (M)
+//
+//   M = H(A | B | K)
+//
+// Params:
+// - kk {v.Value} private session key (K)
+// - aa {v.Value} client ephemeral value (A)
+// - bb {v.Value} server ephemeral value (B)
+// - ss {v.Value} premaster secret (S) (not used here)
+// - salt     {v.Value} random generated salt (s) (not used here)
+// - username {string} plain-text username in UTF8 string (not used here)
+//
+// Returns: {v.Value} validation message (M)
+func (e SrpRb) CalcM(kk, aa, bb, ss, salt v.Value, username string) v.Value {
+	return e.hash(short(aa), short(bb), kk.Hex())
+}
+
+// CalcM2 function: Calculate response validation message (H_AMK)
+//
+//   M2 = H(A | M | K)
+//
+// Params:
+// - kk {v.Value} private session key (K)
+// - aa {v.Value} client ephemeral value (A)
+// - mm {v.Value} validation message (M)
+// - ss {v.Value} premaster secret (S) (not used here)
+//
+// Returns: {v.Value}
+func (e SrpRb) CalcM2(kk, aa, mm, ss v.Value) v.Value {
+	return e.hash(short(aa), short(mm), kk.Hex())
+}
+
+// hash function: hex string concatenation hash
+//
+// Odd-length concatenation is completed with trailing zero nibble,
+// as Ruby's pack('H*') does.
+//
+// Params:
+// - hexes {[]string} hex strings to be hashed
+//
+// Response:
+// - {v.Value}
+func (e SrpRb) hash(hexes ...string) v.Value {
+	str := strings.Join(hexes, "")
+
+	if len(str)%2 != 0 {
+		str = str + "0"
+	}
+
+	return e.crypto.H(v.New(str))
+}
+
+// short function: hex without leading zeros, as Ruby's '%x' does
+//
+// Params:
+// - value {v.Value}
+//
+// Response:
+// - {string}
+func short(value v.Value) string {
+	return value.Int().Text(16)
+}
@@ -0,0 +1,74 @@
+package engine_test
+
+import (
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/value"
+)
+
+var srpRb = e.NewSrpRb(group.New(1024, 2, vectors["N"]))
+
+func TestSrpRbK(t *testing.T) {
+	if srpRb.K().Hex() != vectors["k"] {
+		t.Error("k should be equal")
+	}
+}
+
+func TestSrpRbCalcX(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	subj := srpRb.CalcX("password123", salt, "alice")
+
+	if subj.Hex() != vectors["x"] {
+		t.Error("x should be equal")
+	}
+}
+
+func TestSrpRbCalcU(t *testing.T) {
+	subj := srpRb.CalcU(value.New(vectors["A"]), value.New(vectors["B"]))
+
+	if subj.Hex() != vectors["u"] {
+		t.Error("u should be equal")
+	}
+}
+
+// TestSrpRbCalcM checks K, M and M2 derived from RFC5054 appendix B
+//
+// srp-rb x, k and u are equal to RFC5054 ones for these inputs, so S is
+// checked against appendix B. K, M and M2 are not given by RFC5054, expected
+// values were computed independently of this package with srp-rb formulas
+// (sha1_hex over '%x' hex strings) transcribed to Python hashlib:
+//
+//   K  = sha1_hex('%x' % S)
+//   M  = sha1_hex('%x' % A + '%x' % B + K)
+//   M2 = sha1_hex('%x' % A + M + K)
+func TestSrpRbCalcM(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	x := srpRb.CalcX("password123", salt, "alice")
+	val := srpRb.CalcV(x)
+	aa := srpRb.CalcA(value.New(vectors["a"]))
+	b := value.New(vectors["b"])
+	bb := srpRb.CalcB(b, val)
+	ss := srpRb.CalcServerS(aa, b, val, srpRb.CalcU(aa, bb))
+
+	if aa.Hex() != vectors["A"] || bb.Hex() != vectors["B"] || ss.Hex() != vectors["S"] {
+		t.Fatal("A, B and S should be equal to RFC5054 vectors")
+	}
+
+	kk := srpRb.CalcK(ss)
+
+	if kk.Hex() != "017eefa1cefc5c2e626e21598987f31e0f1b11bb" {
+		t.Error("K should be equal")
+	}
+
+	mm := srpRb.CalcM(kk, aa, bb, ss, value.New("00"), "alice")
+
+	if mm.Hex() != "7c1605558a4e7a5ae79a7f254cb6d04f72608044" {
+		t.Error("M should be equal")
+	}
+
+	if srpRb.CalcM2(kk, aa, mm, ss).Hex() != "bdad4993ffa5d60fd0da4929c5edb8d9e887e69c" {
+		t.Error("M2 should be equal")
+	}
+}
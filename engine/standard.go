@@ -15,6 +15,32 @@ type Standard struct {
 	Engine
 }
 
+// WithProof function: copy of engine with validation message formula
+//
+// Params:
+// - proof {Proof} formula of validation message (M)
+//
+// Response:
+// - {Standard}
+func (e Standard) WithProof(proof Proof) Standard {
+	e.Engine = e.Engine.WithProof(proof)
+	return e
+}
+
+// WithUsernameInM function: copy of engine including H(I) in M
+//
+// Has no effect with CompositeProof, which always includes H(I).
+//
+// Params:
+// - enabled {bool} if true, H(I) is hashed into M
+//
+// Response:
+// - {Standard}
+func (e Standard) WithUsernameInM(enabled bool) Standard {
+	e.usernameInM = enabled
+	return e
+}
+
 // CalcX function: Calculate private key (x)
 //
 // 	 x = KDF(s, p)
@@ -33,16 +59,49 @@ func (e Standard) CalcX(password, salt string) v.Value {
 //
 // 	 M = HMAC(K, A | s | B)
 //
+// With Implicit encoding A, s and B are joined as arithmetic sum (A + s + B),
+// explicit encodings (see Encoding) concatenate encoded values.
+// With username in M (see WithUsernameInM) H(I) is prepended:
+//
+//   M = HMAC(K, H(I) | A | s | B)
+//
+// With CompositeProof (see WithProof) M is calculated as
+//
+//   M = H(H(N) xor H(g) | H(I) | s | A | B | K)
+//
 // Params:
 // - kk {v.Value} private session key (K)
 // - aa {v.Value} client ephemeral value (A)
 // - bb {v.Value} server ephemeral value (B)
 // - ss {v.Value} premaster secret (S) (not used here)
 // - salt     {v.Value} random generated salt (s)
-// - username {string} plain-text username in UTF8 string (not used here)
+// - username {string} plain-text username in UTF8 string
+//                     (with CompositeProof or username in M only)
 //
 // Returns: {v.Value} validation message (M)
 func (e Standard) CalcM(kk, aa, bb, ss, salt v.Value, username string) v.Value {
+	if e.proof == CompositeProof {
+		return e.compositeM(kk, aa, bb, salt, username)
+	}
+
+	if e.usernameInM {
+		hi := e.crypto.H(v.New([]byte(e.identify(username))))
+
+		if e.encoding != Implicit {
+			return e.keyedHash(kk, hi, aa, salt, bb)
+		}
+
+		val := new(big.Int).Add(hi.Int(), aa.Int())
+		val = val.Add(val, salt.Int())
+		val = val.Add(val, bb.Int())
+
+		return e.crypto.KeyedHash(kk, v.New(val))
+	}
+
+	if e.encoding != Implicit {
+		return e.keyedHash(kk, aa, salt, bb)
+	}
+
 	val := big.NewInt(0)
 	val = val.Add(aa.Int(), salt.Int())
 	val = val.Add(val, bb.Int())
@@ -54,6 +113,9 @@ func (e Standard) CalcM(kk, aa, bb, ss, salt v.Value, username string) v.Value {
 //
 //	 M2 = HMAC(K, A | M)
 //
+// With Implicit encoding A and M are joined as arithmetic sum (A + M),
+// explicit encodings (see Encoding) concatenate encoded values.
+//
 // Params:
 // - kk {v.Value} private session key (K)
 // - aa {v.Value} client ephemeral value (A)
@@ -62,6 +124,10 @@ func (e Standard) CalcM(kk, aa, bb, ss, salt v.Value, username string) v.Value {
 //
 // Returns: {v.Value}
 func (e Standard) CalcM2(kk, aa, mm, _ss v.Value) v.Value {
+	if e.encoding != Implicit {
+		return e.keyedHash(kk, aa, mm)
+	}
+
 	val := big.NewInt(0)
 	val = val.Add(aa.Int(), mm.Int())
 
@@ -1 +1,196 @@
+// Package esrp Secure Remote Password protocol (SRP-6a)
 package esrp
+
+import (
+	hash "crypto"
+	"errors"
+	"fmt"
+	"sync"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	g "github.com/nsheremet/esrp/group"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// EphemeralLength {int} length of secret ephemeral values (a, b) in bytes
+const EphemeralLength = 32
+
+// Handshake errors
+var (
+	ErrInvalidState     = errors.New("esrp: method called out of order")
+	ErrInvalidChallenge = errors.New("esrp: invalid server challenge")
+	ErrInvalidPublic    = errors.New("esrp: invalid client public value")
+	ErrInvalidProof     = errors.New("esrp: invalid proof")
+)
+
+// Default engine shared by the facade helpers
+//
+// Applications are expected to configure it once on startup (see SetDefault)
+// and then use short helper calls everywhere. Access is guarded by mutex,
+// so SetDefault and helpers may be called from different goroutines.
+// Until SetDefault is called, Standard engine with SHA256 and
+// RFC5054 2048-bit group is used.
+var (
+	mutex         sync.RWMutex
+	defaultEngine e.Standard
+)
+
+func init() {
+	grp, _ := g.RFC5054(2048)
+	defaultEngine = e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)}
+}
+
+// SetDefault function: configure default engine
+//
+// Params:
+// - engine {engine.Standard} engine used by facade helpers
+func SetDefault(engine e.Standard) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	defaultEngine = engine
+}
+
+// Default function: currently configured default engine
+//
+// Response:
+// - {engine.Standard}
+func Default() e.Standard {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	return defaultEngine
+}
+
+// Verifier function: Calculate password verifier (v) with default engine
+//
+//   v = g^x
+//
+// Params:
+// - password {string} plain-text password in UTF8 string
+// - salt     {string} random generated salt (s) in hex
+//
+// Response:
+// - {esrp.Value} password verifier (v)
+func Verifier(password, salt string) v.Value {
+	engine := Default()
+
+	return engine.CalcV(engine.CalcX(password, salt))
+}
+
+// knownAnswer {map[string]string} RFC5054 appendix B test vector (1024-bit group)
+var knownAnswer = map[string]string{
+	"x": "94b7555aabe9127cc58ccf4993db6cf84d16c124",
+	"a": "60975527035cf2ad1989806f0407210bc81edc04e2762a56afd529ddda2d4393",
+	"b": "e487cb59d31ac550471e81f00f6928e01dda08e974a004f49e61f5d105284d20",
+	"v": "7e273de8696ffc4f4e337d05b4b375beb0dde1569e8fa00a9886d8129bada1f1" +
+		"822223ca1a605b530e379ba4729fdc59f105b4787e5186f5c671085a1447b52a" +
+		"48cf1970b4fb6f8400bbf4cebfbb168152e08ab5ea53d15c1aff87b2b9da6e04" +
+		"e058ad51cc72bfc9033b564e26480d78e955a5e29e7ab245db2be315e2099afb",
+	"A": "61d5e490f6f1b79547b0704c436f523dd0e560f0c64115bb72557ec44352e890" +
+		"3211c04692272d8b2d1a5358a2cf1b6e0bfcf99f921530ec8e39356179eae45e" +
+		"42ba92aeaced825171e1e8b9af6d9c03e1327f44be087ef06530e69f66615261" +
+		"eef54073ca11cf5858f0edfdfe15efeab349ef5d76988a3672fac47b0769447b",
+	"B": "bd0c61512c692c0cb6d041fa01bb152d4916a1e77af46ae105393011baf38964" +
+		"dc46a0670dd125b95a981652236f99d9b681cbf87837ec996c6da04453728610" +
+		"d0c6ddb58b318885d7d82c7f8deb75ce7bd4fbaa37089e6f9c6059f388838e7a" +
+		"00030b331eb76840910440b1b27aaeaeeb4012b7d7665238a8e3fb004b117b58",
+	"u": "ce38b9593487da98554ed47d70a7ae5f462ef019",
+	"S": "b0dc82babcf30674ae450c0287745e7990a3381f63b387aaf271a10d233861e3" +
+		"59b48220f7c4693c9ae12b0a6f67809f0876e2d013800d6c41bb59b6d5979b5c" +
+		"00a172b4a2a5903a0bdcaf8a709585eb2afafa8f3499b200210dcc1f10eb3394" +
+		"3cd67fc88a2f39a4be5bec4ec0a3212dc346d7e474b29ede8a469ffeca686e5a",
+}
+
+// Healthcheck function: verify default engine is operational
+//
+// Suitable for readiness probes, so a broken crypto backend is detected
+// before traffic is routed. Checks that:
+// - default engine is configured
+// - random generator produces values of requested length, which don't repeat
+// - RFC5054 engine with backend of default engine (see engine.WithModExp)
+//   reproduces appendix B test vector, so regressions affecting both sides
+//   of handshake alike are caught too
+// - full handshake with generated credentials gives equal client and server S
+//
+// Response:
+// - {error} nil if default engine is healthy
+func Healthcheck() error {
+	engine := Default()
+	crypto := engine.Crypto()
+
+	if crypto == nil {
+		return errors.New("esrp: default engine is not configured")
+	}
+
+	first := crypto.Random(32)
+	second := crypto.Random(32)
+
+	if len(first.Bytes()) != 32 || len(second.Bytes()) != 32 {
+		return errors.New("esrp: random generator returned value of wrong length")
+	}
+
+	if crypto.SecureCompare(first, second) {
+		return errors.New("esrp: random generator repeats values")
+	}
+
+	if err := checkKnownAnswer(engine); err != nil {
+		return err
+	}
+
+	salt := crypto.Random(16)
+	x := engine.CalcX("healthcheck", salt.Hex())
+	val := engine.CalcV(x)
+	a := crypto.Random(32)
+	b := crypto.Random(32)
+	aa := engine.CalcA(a)
+	bb := engine.CalcB(b, val)
+	u := engine.CalcU(aa, bb)
+
+	if !crypto.SecureCompare(engine.CalcClientS(bb, a, x, u), engine.CalcServerS(aa, b, val, u)) {
+		return errors.New("esrp: client and server premaster secrets differ")
+	}
+
+	return nil
+}
+
+// checkKnownAnswer function: RFC5054 appendix B test vector check
+//
+// Params:
+// - engine {engine.Standard} engine providing modular exponentiation backend
+//
+// Response:
+// - {error} nil if every value matches the vector
+func checkKnownAnswer(engine e.Standard) error {
+	grp, _ := g.RFC5054(1024)
+	ref := e.RFC5054{Engine: e.NewRFC5054(grp).Engine.WithModExp(engine.ModExp())}
+
+	x := ref.CalcX("password123", v.New("beb25379d1a8581eb5a727673a2441ee"), "alice")
+	a, b := v.New(knownAnswer["a"]), v.New(knownAnswer["b"])
+	val := ref.CalcV(x)
+	aa := ref.CalcA(a)
+	bb := ref.CalcB(b, val)
+	u := ref.CalcU(aa, bb)
+
+	values := map[string]v.Value{
+		"x": x,
+		"v": val,
+		"A": aa,
+		"B": bb,
+		"u": u,
+		"S": ref.CalcClientS(bb, a, x, u),
+	}
+
+	for name, value := range values {
+		if value.Hex() != knownAnswer[name] {
+			return fmt.Errorf("esrp: known answer check failed for %s", name)
+		}
+	}
+
+	if ref.CalcServerS(aa, b, val, u).Hex() != knownAnswer["S"] {
+		return fmt.Errorf("esrp: known answer check failed for server S")
+	}
+
+	return nil
+}
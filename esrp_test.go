@@ -0,0 +1,121 @@
+package esrp_test
+
+import (
+	hash "crypto"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/nsheremet/esrp"
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	g "github.com/nsheremet/esrp/group"
+)
+
+var grp = g.New(1024, 2,
+	"EEAF0AB9ADB38DD69C33F80AFA8FC5E86072618775FF3C0B9EA2314C9C256576"+
+		"D674DF7496EA81D3383B4813D692C6E0E0D5D8E250B98BE48E495C1D6089DAD1"+
+		"5DC7D7B46154D6B6CE8EF4AD69B15D4982559B297BCF1885C529F566660E57EC"+
+		"68EDBC3C05726CC02FD4CBF4976EAA9AFD5138FE8376435B9FC61D2FC0EB06E3")
+
+// restoreDefault function: restore default engine when test is done
+//
+// Tests changing default engine call it first, so the engine configured
+// on package init is seen by TestDefaultUnconfigured in any order.
+func restoreDefault(t *testing.T) {
+	previous := esrp.Default()
+	t.Cleanup(func() { esrp.SetDefault(previous) })
+}
+
+func TestDefaultUnconfigured(t *testing.T) {
+	rfc5054, _ := g.RFC5054(2048)
+	engine := e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), rfc5054)}
+
+	if esrp.Verifier("verysecure", "0451").Hex() != engine.CalcV(engine.CalcX("verysecure", "0451")).Hex() {
+		t.Error("default engine should use SHA256 and RFC5054 2048-bit group")
+	}
+
+	if err := esrp.Healthcheck(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	restoreDefault(t)
+	engine := e.Standard{Engine: e.New(c.NewStandard(hash.SHA1), grp)}
+	esrp.SetDefault(engine)
+
+	if esrp.Default().K().Hex() != engine.K().Hex() {
+		t.Error("default engine should be equal")
+	}
+}
+
+func TestSetDefaultConcurrent(t *testing.T) {
+	restoreDefault(t)
+	var wg sync.WaitGroup
+	salt := "0451"
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			esrp.SetDefault(e.Standard{Engine: e.New(c.NewStandardWithParams(hash.SHA1, c.StandardParams{LegacyKdf: true, Mac: c.LegacyConcat}), grp)})
+		}()
+
+		go func() {
+			defer wg.Done()
+			esrp.Verifier("verysecure", salt)
+		}()
+	}
+
+	wg.Wait()
+
+	expected := esrp.Default().CalcV(esrp.Default().CalcX("verysecure", salt))
+
+	if esrp.Verifier("verysecure", salt).Hex() != expected.Hex() {
+		t.Error("verifier should be equal")
+	}
+}
+
+func TestHealthcheck(t *testing.T) {
+	restoreDefault(t)
+	esrp.SetDefault(e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)})
+
+	for i := 0; i < 10; i++ {
+		if err := esrp.Healthcheck(); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// squaringModExp struct: broken backend using g^2 as generator,
+// consistent on both sides of handshake
+type squaringModExp struct{}
+
+func (squaringModExp) Exp(base, exponent, modulus *big.Int) *big.Int {
+	if base.Cmp(big.NewInt(2)) == 0 {
+		base = big.NewInt(4)
+	}
+
+	return new(big.Int).Exp(base, exponent, modulus)
+}
+
+func TestHealthcheckKnownAnswer(t *testing.T) {
+	restoreDefault(t)
+	engine := e.New(c.NewStandard(hash.SHA256), grp)
+	esrp.SetDefault(e.Standard{Engine: engine.WithModExp(squaringModExp{})})
+
+	if esrp.Healthcheck() == nil {
+		t.Error("should fail with backend not matching known answer")
+	}
+}
+
+func TestHealthcheckNotConfigured(t *testing.T) {
+	restoreDefault(t)
+	esrp.SetDefault(e.Standard{})
+
+	if esrp.Healthcheck() == nil {
+		t.Error("should fail without configured engine")
+	}
+}
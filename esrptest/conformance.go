@@ -0,0 +1,100 @@
+package esrptest
+
+import (
+	"math/rand"
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// conformanceRuns {int} number of random handshakes checked by Conformance
+const conformanceRuns = 16
+
+// Conformance function: check engine implementation against SRP invariants
+//
+// Intended to be called from tests of third-party engines:
+//
+//   func TestConformance(t *testing.T) {
+//     esrptest.Conformance(t, NewCustom(group))
+//   }
+//
+// Checks that:
+// - x and v are deterministic, x depends on password and salt (username
+//   is not varied, engines like Standard ignore it)
+// - client and server premaster secrets (S) are equal
+// - A and B are valid public values, zero is rejected
+// - M and M2 are deterministic, M depends on K, M2 differs from M
+//
+// Params:
+// - t    {testing.TB}
+// - impl {engine.Conformable} engine implementation
+func Conformance(t testing.TB, impl e.Conformable) {
+	t.Helper()
+
+	random := rand.New(rand.NewSource(1))
+
+	if impl.IsValidPublic(v.New(make([]byte, 1))) {
+		t.Error("esrptest: conformance: zero public value should be rejected")
+	}
+
+	for run := 0; run < conformanceRuns; run++ {
+		salt := v.New(randomBytes(random, 16))
+		a := v.New(randomBytes(random, 32))
+		b := v.New(randomBytes(random, 32))
+
+		x := impl.CalcX("password", salt, "alice")
+
+		if !equal(x, impl.CalcX("password", salt, "alice")) {
+			t.Errorf("esrptest: conformance: x should be deterministic (run %d)", run)
+		}
+
+		if equal(x, impl.CalcX("passwore", salt, "alice")) || equal(x, impl.CalcX("password", v.New(randomBytes(random, 16)), "alice")) {
+			t.Errorf("esrptest: conformance: x should depend on password and salt (run %d)", run)
+		}
+
+		val := impl.CalcV(x)
+
+		if !equal(val, impl.CalcV(x)) {
+			t.Errorf("esrptest: conformance: v should be deterministic (run %d)", run)
+		}
+
+		aa := impl.CalcA(a)
+		bb := impl.CalcB(b, val)
+
+		if !impl.IsValidPublic(aa) || !impl.IsValidPublic(bb) {
+			t.Errorf("esrptest: conformance: A and B should be valid public values (run %d)", run)
+		}
+
+		u := impl.CalcU(aa, bb)
+		clientS := impl.CalcClientS(bb, a, x, u)
+		serverS := impl.CalcServerS(aa, b, val, u)
+
+		if !equal(clientS, serverS) {
+			t.Errorf("esrptest: conformance: client and server S should be equal (run %d)", run)
+			continue
+		}
+
+		kk := impl.CalcK(serverS)
+		mm := impl.CalcM(kk, aa, bb, serverS, salt, "alice")
+
+		if !equal(mm, impl.CalcM(kk, aa, bb, clientS, salt, "alice")) {
+			t.Errorf("esrptest: conformance: M should be deterministic (run %d)", run)
+		}
+
+		if equal(mm, impl.CalcM(impl.CalcK(v.New(randomBytes(random, 32))), aa, bb, serverS, salt, "alice")) {
+			t.Errorf("esrptest: conformance: M should depend on K (run %d)", run)
+		}
+
+		mm2 := impl.CalcM2(kk, aa, mm, serverS)
+
+		if !equal(mm2, impl.CalcM2(kk, aa, mm, clientS)) || equal(mm2, mm) {
+			t.Errorf("esrptest: conformance: M2 should be deterministic and differ from M (run %d)", run)
+		}
+	}
+}
+
+// equal function: values are equal by bytes
+func equal(a, b v.Value) bool {
+	return a.Hex() == b.Hex()
+}
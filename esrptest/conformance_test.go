@@ -0,0 +1,35 @@
+package esrptest_test
+
+import (
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/esrptest"
+	"github.com/nsheremet/esrp/value"
+)
+
+// brokenServerS struct: engine with wrong server premaster secret
+type brokenServerS struct {
+	e.SrpRb
+}
+
+func (b brokenServerS) CalcServerS(aa, bb, val, u value.Value) value.Value {
+	return b.SrpRb.CalcServerS(aa, bb, b.CalcV(val), u)
+}
+
+func TestConformanceSrpRb(t *testing.T) {
+	esrptest.Conformance(t, e.NewSrpRb(grp))
+}
+
+func TestConformanceRFC5054(t *testing.T) {
+	esrptest.Conformance(t, e.NewRFC5054(grp))
+}
+
+func TestConformanceBroken(t *testing.T) {
+	r := &recorder{TB: t}
+	esrptest.Conformance(r, brokenServerS{e.NewSrpRb(grp)})
+
+	if len(r.errors) == 0 {
+		t.Error("broken engine should not conform")
+	}
+}
@@ -0,0 +1,156 @@
+// Package esrptest testing helpers for custom engine configurations
+package esrptest
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"testing"
+	"time"
+
+	e "github.com/nsheremet/esrp/engine"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Calculator interface: engine methods exercised by DifferentialRun
+type Calculator interface {
+	CalcX(password string, salt v.Value, username string) v.Value
+	CalcV(x v.Value) v.Value
+	CalcA(a v.Value) v.Value
+	CalcB(b, val v.Value) v.Value
+	CalcU(aa, bb v.Value) v.Value
+	CalcClientS(bb, a, x, u v.Value) v.Value
+	CalcServerS(aa, b, val, u v.Value) v.Value
+	CalcK(ss v.Value) v.Value
+}
+
+// Values compared by DifferentialRun
+const (
+	X = "x"
+	V = "v"
+	A = "A"
+	B = "B"
+	U = "u"
+	S = "S"
+	K = "K"
+)
+
+// Expect struct: relation between two engine configurations
+//
+// Values not listed in Agree or Differ are not compared. Differ values
+// must differ in every run.
+type Expect struct {
+	Agree  []string
+	Differ []string
+	Runs   int   // number of random cases, defaults to 32
+	Seed   int64 // random seed, time based if zero
+}
+
+// standard struct: adapts engine.Standard to Calculator
+type standard struct {
+	e.Standard
+}
+
+// CalcX function: engine.Standard takes salt as hex and ignores username
+func (s standard) CalcX(password string, salt v.Value, username string) v.Value {
+	return s.Standard.CalcX(password, salt.Hex())
+}
+
+// Standard function: engine.Standard as Calculator
+//
+// Params:
+// - engine {engine.Standard}
+//
+// Response:
+// - {Calculator}
+func Standard(engine e.Standard) Calculator {
+	return standard{engine}
+}
+
+// inputs struct: random credentials and ephemerals of single run
+type inputs struct {
+	password string
+	username string
+	salt     v.Value
+	a        v.Value
+	b        v.Value
+}
+
+// DifferentialRun function: compare two engine configurations on random inputs
+//
+// Both engines get the same random credentials and ephemerals. Every engine
+// must agree with itself (client and server S are equal), and values listed
+// in expect must agree/differ between engines. Failures are reported with
+// the seed, so run can be reproduced with Expect.Seed.
+//
+// Params:
+// - t      {testing.TB}
+// - first  {Calculator}
+// - second {Calculator}
+// - expect {Expect}
+func DifferentialRun(t testing.TB, first, second Calculator, expect Expect) {
+	t.Helper()
+
+	runs := expect.Runs
+	seed := expect.Seed
+
+	if runs == 0 {
+		runs = 32
+	}
+
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	random := rand.New(rand.NewSource(seed))
+
+	for run := 0; run < runs; run++ {
+		in := inputs{
+			password: hex.EncodeToString(randomBytes(random, 12)),
+			username: hex.EncodeToString(randomBytes(random, 8)),
+			salt:     v.New(randomBytes(random, 16)),
+			a:        v.New(randomBytes(random, 32)),
+			b:        v.New(randomBytes(random, 32)),
+		}
+
+		left := calc(t, first, in, "first", seed, run)
+		right := calc(t, second, in, "second", seed, run)
+
+		for _, name := range expect.Agree {
+			if left[name].Hex() != right[name].Hex() {
+				t.Errorf("esrptest: %s should agree (seed %d, run %d)", name, seed, run)
+			}
+		}
+
+		for _, name := range expect.Differ {
+			if left[name].Hex() == right[name].Hex() {
+				t.Errorf("esrptest: %s should differ (seed %d, run %d)", name, seed, run)
+			}
+		}
+	}
+}
+
+// calc function: full handshake values of single engine
+func calc(t testing.TB, engine Calculator, in inputs, label string, seed int64, run int) map[string]v.Value {
+	t.Helper()
+
+	x := engine.CalcX(in.password, in.salt, in.username)
+	val := engine.CalcV(x)
+	aa := engine.CalcA(in.a)
+	bb := engine.CalcB(in.b, val)
+	u := engine.CalcU(aa, bb)
+	ss := engine.CalcServerS(aa, in.b, val, u)
+
+	if engine.CalcClientS(bb, in.a, x, u).Hex() != ss.Hex() {
+		t.Errorf("esrptest: %s engine client and server S differ (seed %d, run %d)", label, seed, run)
+	}
+
+	return map[string]v.Value{X: x, V: val, A: aa, B: bb, U: u, S: ss, K: engine.CalcK(ss)}
+}
+
+// randomBytes function: length random bytes
+func randomBytes(random *rand.Rand, length int) []byte {
+	bytes := make([]byte, length)
+	random.Read(bytes)
+
+	return bytes
+}
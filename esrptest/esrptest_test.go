@@ -0,0 +1,68 @@
+package esrptest_test
+
+import (
+	hash "crypto"
+	"fmt"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/esrptest"
+	g "github.com/nsheremet/esrp/group"
+)
+
+var grp = g.New(1024, 2,
+	"EEAF0AB9ADB38DD69C33F80AFA8FC5E86072618775FF3C0B9EA2314C9C256576"+
+		"D674DF7496EA81D3383B4813D692C6E0E0D5D8E250B98BE48E495C1D6089DAD1"+
+		"5DC7D7B46154D6B6CE8EF4AD69B15D4982559B297BCF1885C529F566660E57EC"+
+		"68EDBC3C05726CC02FD4CBF4976EAA9AFD5138FE8376435B9FC61D2FC0EB06E3")
+
+// recorder struct: testing.TB collecting errors instead of failing
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestDifferentialRunGnuTLSSrpRb(t *testing.T) {
+	esrptest.DifferentialRun(t, e.NewGnuTLS(grp), e.NewSrpRb(grp), esrptest.Expect{
+		Agree: []string{esrptest.X, esrptest.V, esrptest.A, esrptest.B, esrptest.U, esrptest.S},
+		Runs:  8,
+	})
+}
+
+func TestDifferentialRunStandardHashes(t *testing.T) {
+	sha1 := esrptest.Standard(e.Standard{Engine: e.New(c.NewStandard(hash.SHA1), grp)})
+	sha256 := esrptest.Standard(e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)})
+
+	esrptest.DifferentialRun(t, sha1, sha256, esrptest.Expect{
+		Agree:  []string{esrptest.A},
+		Differ: []string{esrptest.X, esrptest.V, esrptest.B, esrptest.U, esrptest.S, esrptest.K},
+		Runs:   4,
+	})
+}
+
+func TestDifferentialRunReportsMismatch(t *testing.T) {
+	sha1 := esrptest.Standard(e.Standard{Engine: e.New(c.NewStandard(hash.SHA1), grp)})
+	sha256 := esrptest.Standard(e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)})
+	r := &recorder{TB: t}
+
+	esrptest.DifferentialRun(r, sha1, sha256, esrptest.Expect{
+		Agree: []string{esrptest.X},
+		Runs:  2,
+		Seed:  1,
+	})
+
+	if len(r.errors) != 2 {
+		t.Error("every run should report mismatch")
+	}
+}
@@ -0,0 +1,170 @@
+package esrptest
+
+import (
+	hash "crypto"
+	"sync/atomic"
+	"time"
+
+	c "github.com/nsheremet/esrp/crypto"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// FaultyCrypto struct: crypto wrapper with togglable fault injection
+//
+// Wraps any crypto engine and passes calls through until a fault is enabled.
+// Faults may be toggled from any goroutine while the wrapper is in use,
+// so application error handling can be exercised under chaos-testing.
+type FaultyCrypto struct {
+	c.Crypto
+	failRandom  int32
+	shortRandom int32
+	corruptMac  int32
+	kdfDelay    int64
+}
+
+// NewFaultyCrypto function Constructor
+//
+// Params:
+// - crypto {esrp.Crypto} crypto engine to wrap
+//
+// Response:
+// - {*FaultyCrypto}
+func NewFaultyCrypto(crypto c.Crypto) *FaultyCrypto {
+	return &FaultyCrypto{Crypto: crypto}
+}
+
+// FailRandom function: Random returns zero bytes (broken generator)
+//
+// Params:
+// - enabled {bool}
+func (f *FaultyCrypto) FailRandom(enabled bool) {
+	atomic.StoreInt32(&f.failRandom, flag(enabled))
+}
+
+// ShortRandom function: Random returns half of requested bytes
+//
+// Params:
+// - enabled {bool}
+func (f *FaultyCrypto) ShortRandom(enabled bool) {
+	atomic.StoreInt32(&f.shortRandom, flag(enabled))
+}
+
+// CorruptKeyedHash function: KeyedHash returns value with flipped bit,
+// so validation messages (M, M2) never match
+//
+// Params:
+// - enabled {bool}
+func (f *FaultyCrypto) CorruptKeyedHash(enabled bool) {
+	atomic.StoreInt32(&f.corruptMac, flag(enabled))
+}
+
+// SlowKDF function: PasswordHash is delayed, zero disables delay
+//
+// Params:
+// - delay {time.Duration}
+func (f *FaultyCrypto) SlowKDF(delay time.Duration) {
+	atomic.StoreInt64(&f.kdfDelay, int64(delay))
+}
+
+// Reset function: disable all faults
+func (f *FaultyCrypto) Reset() {
+	f.FailRandom(false)
+	f.ShortRandom(false)
+	f.CorruptKeyedHash(false)
+	f.SlowKDF(0)
+}
+
+// Random function: random string generator with injected faults
+//
+// Params:
+// - bytesLength {int} length of desired generated bytes
+//
+// Response:
+// - {esrp.Value}
+func (f *FaultyCrypto) Random(bytesLength int) v.Value {
+	if atomic.LoadInt32(&f.shortRandom) != 0 {
+		bytesLength = bytesLength / 2
+	}
+
+	if atomic.LoadInt32(&f.failRandom) != 0 {
+		return v.New(make([]byte, bytesLength))
+	}
+
+	return f.Crypto.Random(bytesLength)
+}
+
+// PasswordHash function: password-based key derivation with injected delay
+//
+// Params:
+// - salt     {esrp.Value} random generated salt
+// - password {string} plain-text password
+//
+// Response:
+// - {esrp.Value}
+func (f *FaultyCrypto) PasswordHash(salt v.Value, password string) v.Value {
+	if delay := time.Duration(atomic.LoadInt64(&f.kdfDelay)); delay > 0 {
+		time.Sleep(delay)
+	}
+
+	return f.Crypto.PasswordHash(salt, password)
+}
+
+// KeyedHash function: keyed hash transform with injected corruption
+//
+// Params:
+// - key {esrp.Value}
+// - msg {esrp.Value}
+//
+// Response:
+// - {esrp.Value}
+func (f *FaultyCrypto) KeyedHash(key, msg v.Value) v.Value {
+	mac := f.Crypto.KeyedHash(key, msg)
+
+	if atomic.LoadInt32(&f.corruptMac) == 0 {
+		return mac
+	}
+
+	bytes := mac.Bytes()
+	corrupted := append([]byte{}, bytes...)
+
+	if len(corrupted) == 0 {
+		return v.New([]byte{1})
+	}
+
+	corrupted[len(corrupted)-1] ^= 1
+
+	return v.New(corrupted)
+}
+
+// Hash function: hash type of wrapped engine, so policies see it unchanged
+//
+// Response:
+// - {crypto.Hash} zero if wrapped engine doesn't describe its hash
+func (f *FaultyCrypto) Hash() hash.Hash {
+	return c.HashOf(f.Crypto)
+}
+
+// KDFParams function: password-based key derivation parameters of wrapped engine
+//
+// Response:
+// - {crypto.KDFParams} zero if wrapped engine doesn't describe its KDF
+func (f *FaultyCrypto) KDFParams() c.KDFParams {
+	return c.KDFParamsOf(f.Crypto)
+}
+
+// MacMode function: keyed hash transform of wrapped engine
+//
+// Response:
+// - {crypto.MacMode} UnknownMac if wrapped engine doesn't describe its mac
+func (f *FaultyCrypto) MacMode() c.MacMode {
+	return c.MacModeOf(f.Crypto)
+}
+
+// flag function: bool as int32 for atomic access
+func flag(enabled bool) int32 {
+	if enabled {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,92 @@
+package esrptest_test
+
+import (
+	hash "crypto"
+	"testing"
+	"time"
+
+	"github.com/nsheremet/esrp"
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/esrptest"
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestFaultyCryptoPassThrough(t *testing.T) {
+	crypto := c.NewStandard(hash.SHA256)
+	faulty := esrptest.NewFaultyCrypto(crypto)
+	key := value.New("0c486f95")
+
+	if c.Conformance(faulty) != nil {
+		t.Error("faulty crypto without faults should conform")
+	}
+
+	if faulty.KeyedHash(key, key).Hex() != crypto.KeyedHash(key, key).Hex() {
+		t.Error("keyed hash should be equal")
+	}
+}
+
+func TestFaultyCryptoRandom(t *testing.T) {
+	faulty := esrptest.NewFaultyCrypto(c.NewStandard(hash.SHA256))
+	faulty.ShortRandom(true)
+
+	if len(faulty.Random(32).Bytes()) != 16 {
+		t.Error("random should be short")
+	}
+
+	faulty.Reset()
+	faulty.FailRandom(true)
+
+	if faulty.Random(32).Hex() != faulty.Random(32).Hex() {
+		t.Error("random should repeat")
+	}
+}
+
+func TestFaultyCryptoCorruptKeyedHash(t *testing.T) {
+	crypto := c.NewStandard(hash.SHA256)
+	faulty := esrptest.NewFaultyCrypto(crypto)
+	key := value.New("0c486f95")
+	faulty.CorruptKeyedHash(true)
+
+	if faulty.KeyedHash(key, key).Hex() == crypto.KeyedHash(key, key).Hex() {
+		t.Error("keyed hash should be corrupted")
+	}
+}
+
+func TestFaultyCryptoSlowKDF(t *testing.T) {
+	faulty := esrptest.NewFaultyCrypto(c.NewStandardWithParams(hash.SHA256, c.StandardParams{LegacyKdf: true}))
+	faulty.SlowKDF(20 * time.Millisecond)
+	start := time.Now()
+	faulty.PasswordHash(value.New("0451"), "password")
+
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("password hash should be delayed")
+	}
+}
+
+func TestFaultyCryptoHealthcheck(t *testing.T) {
+	previous := esrp.Default()
+	t.Cleanup(func() { esrp.SetDefault(previous) })
+
+	faulty := esrptest.NewFaultyCrypto(c.NewStandard(hash.SHA256))
+	esrp.SetDefault(e.Standard{Engine: e.New(faulty, grp)})
+
+	if err := esrp.Healthcheck(); err != nil {
+		t.Error(err)
+	}
+
+	faulty.FailRandom(true)
+
+	if esrp.Healthcheck() == nil {
+		t.Error("healthcheck should detect broken random")
+	}
+}
+
+func TestFaultyCryptoDescribesWrapped(t *testing.T) {
+	standard := c.NewStandardWithParams(hash.SHA1, c.StandardParams{LegacyKdf: true, Mac: c.LegacyConcat})
+	faulty := esrptest.NewFaultyCrypto(standard)
+
+	if faulty.Hash() != standard.Hash() || faulty.KDFParams() != standard.KDFParams() || faulty.MacMode() != standard.MacMode() {
+		t.Error("faulty crypto should describe wrapped engine")
+	}
+}
@@ -0,0 +1,41 @@
+package esrptest
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	g "github.com/nsheremet/esrp/group"
+)
+
+// BenchmarkModExp function: benchmark backend on g^b for given groups
+//
+// Secret ephemeral value (b) is fixed 256-bit number.
+//
+// Intended to compare custom backend (see engine.WithModExp) with math/big:
+//
+//   func BenchmarkModExp(b *testing.B) {
+//     esrptest.BenchmarkModExp(b, engine.BigModExp{}, groups...)
+//     esrptest.BenchmarkModExp(b, Custom{}, groups...)
+//   }
+//
+// Params:
+// - b      {*testing.B}
+// - impl   {engine.ModExp} backend
+// - groups {[]esrp.Group}
+func BenchmarkModExp(b *testing.B, impl e.ModExp, groups ...g.Group) {
+	exponent := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+	for _, grp := range groups {
+		base, modulus := grp.G.Int(), grp.N.Int()
+
+		b.Run(fmt.Sprintf("%T/%d", impl, grp.PrimeLength), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				impl.Exp(base, exponent, modulus)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Package filecrypt file and stream encryption keyed by SRP-derived secrets
+//
+// Covers the common "password-derived key for user data" need without
+// a second KDF pass over the password: the key is derived from private
+// session key (K), or from private key (x) for client-side vaults.
+//
+// Format:
+//
+//   header = magic "ESRP" | version | salt
+//   chunk  = AES-256-GCM(key, nonce, plaintext chunk)
+//   key    = HKDF-SHA256(secret, salt, "esrp filecrypt v1")
+//   nonce  = chunk counter | final flag
+//
+// The final chunk is flagged inside the nonce, so truncation
+// and reordering of the stream are detected on decryption.
+package filecrypt
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	v "github.com/nsheremet/esrp/value"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	version   = 1
+	saltSize  = 16
+	keySize   = 32
+	chunkSize = 64 * 1024
+	info      = "esrp filecrypt v1"
+)
+
+var magic = []byte("ESRP")
+
+// ErrInvalidHeader is returned when the stream isn't produced by Encrypt
+var ErrInvalidHeader = errors.New("filecrypt: invalid header")
+
+// ErrAuthentication is returned when the stream is corrupted, truncated or the key is wrong
+var ErrAuthentication = errors.New("filecrypt: message authentication failed")
+
+// Encrypt function: encrypt stream with key derived from secret
+//
+// Params:
+// - dst    {io.Writer} ciphertext destination
+// - src    {io.Reader} plaintext source
+// - secret {esrp.Value} private session key (K) or private key (x)
+//
+// Response:
+// - {error}
+func Encrypt(dst io.Writer, src io.Reader, secret v.Value) error {
+	salt := make([]byte, saltSize)
+
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(secret, salt)
+
+	if err != nil {
+		return err
+	}
+
+	header := append(append(append([]byte{}, magic...), version), salt...)
+
+	if _, err = dst.Write(header); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReaderSize(src, chunkSize)
+	buf := make([]byte, chunkSize)
+
+	for counter := uint64(0); ; counter++ {
+		n, final, err := readChunk(reader, buf)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err = dst.Write(aead.Seal(nil, nonce(counter, final), buf[:n], nil)); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// Decrypt function: decrypt stream produced by Encrypt
+//
+// Every chunk is authenticated before it's written to dst. If the stream
+// is truncated, chunks preceding the truncation point are already written
+// when ErrAuthentication is returned, so dst should be discarded on error.
+//
+// Params:
+// - dst    {io.Writer} plaintext destination
+// - src    {io.Reader} ciphertext source
+// - secret {esrp.Value} the same secret as used for Encrypt
+//
+// Response:
+// - {error}
+func Decrypt(dst io.Writer, src io.Reader, secret v.Value) error {
+	header := make([]byte, len(magic)+1+saltSize)
+
+	if _, err := io.ReadFull(src, header); err != nil {
+		return ErrInvalidHeader
+	}
+
+	if string(header[:len(magic)]) != string(magic) || header[len(magic)] != version {
+		return ErrInvalidHeader
+	}
+
+	aead, err := newAEAD(secret, header[len(magic)+1:])
+
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReaderSize(src, chunkSize+aead.Overhead())
+	buf := make([]byte, chunkSize+aead.Overhead())
+
+	for counter := uint64(0); ; counter++ {
+		n, final, err := readChunk(reader, buf)
+
+		if err != nil {
+			return err
+		}
+
+		plaintext, err := aead.Open(nil, nonce(counter, final), buf[:n], nil)
+
+		if err != nil {
+			return ErrAuthentication
+		}
+
+		if _, err = dst.Write(plaintext); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// newAEAD function: derive key and construct AES-256-GCM
+//
+// Params:
+// - secret {esrp.Value}
+// - salt   {[]byte}
+//
+// Response:
+// - {cipher.AEAD}
+// - {error}
+func newAEAD(secret v.Value, salt []byte) (cipher.AEAD, error) {
+	key := make([]byte, keySize)
+
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret.Bytes(), salt, []byte(info)), key); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// readChunk function: read next chunk and detect the final one
+//
+// Params:
+// - reader {*bufio.Reader}
+// - buf    {[]byte} chunk buffer
+//
+// Response:
+// - {int}   chunk length
+// - {bool}  true if it's the final chunk
+// - {error}
+func readChunk(reader *bufio.Reader, buf []byte) (int, bool, error) {
+	n, err := io.ReadFull(reader, buf)
+
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return n, true, nil
+	}
+
+	if err != nil {
+		return n, false, err
+	}
+
+	if _, err = reader.Peek(1); err == io.EOF {
+		return n, true, nil
+	}
+
+	return n, false, err
+}
+
+// nonce function: chunk nonce
+//
+// Params:
+// - counter {uint64} chunk number
+// - final   {bool}   true for the final chunk
+//
+// Response:
+// - {[]byte}
+func nonce(counter uint64, final bool) []byte {
+	res := make([]byte, 12)
+	binary.BigEndian.PutUint64(res[3:11], counter)
+
+	if final {
+		res[11] = 1
+	}
+
+	return res
+}
@@ -0,0 +1,68 @@
+package filecrypt_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/nsheremet/esrp/filecrypt"
+	"github.com/nsheremet/esrp/value"
+)
+
+var secret = value.New("0c486f95b2986a6f8a2b1f9368d7472dc615f1b5")
+
+func roundTrip(t *testing.T, size int) {
+	plaintext := make([]byte, size)
+	rand.Read(plaintext)
+
+	var encrypted, decrypted bytes.Buffer
+
+	if err := filecrypt.Encrypt(&encrypted, bytes.NewReader(plaintext), secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := filecrypt.Decrypt(&decrypted, bytes.NewReader(encrypted.Bytes()), secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(plaintext, decrypted.Bytes()) {
+		t.Errorf("decrypted %d bytes should be equal to plaintext", size)
+	}
+}
+
+func TestFilecryptRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, 64*1024 - 1, 64 * 1024, 64*1024 + 1, 3 * 64 * 1024} {
+		roundTrip(t, size)
+	}
+}
+
+func TestFilecryptWrongKey(t *testing.T) {
+	var encrypted bytes.Buffer
+	filecrypt.Encrypt(&encrypted, bytes.NewReader([]byte("attack at dawn")), secret)
+
+	err := filecrypt.Decrypt(&bytes.Buffer{}, &encrypted, value.New("00ff"))
+
+	if err != filecrypt.ErrAuthentication {
+		t.Error("should fail authentication with wrong key")
+	}
+}
+
+func TestFilecryptTruncated(t *testing.T) {
+	var encrypted bytes.Buffer
+	filecrypt.Encrypt(&encrypted, bytes.NewReader(make([]byte, 2*64*1024+10)), secret)
+
+	truncated := encrypted.Bytes()[:5+16+64*1024+16]
+	err := filecrypt.Decrypt(&bytes.Buffer{}, bytes.NewReader(truncated), secret)
+
+	if err != filecrypt.ErrAuthentication {
+		t.Error("should detect truncation")
+	}
+}
+
+func TestFilecryptInvalidHeader(t *testing.T) {
+	err := filecrypt.Decrypt(&bytes.Buffer{}, bytes.NewReader([]byte("not encrypted at all")), secret)
+
+	if err != filecrypt.ErrInvalidHeader {
+		t.Error("should reject invalid header")
+	}
+}
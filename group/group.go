@@ -2,6 +2,8 @@
 package group
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
 	v "github.com/nsheremet/esrp/value"
@@ -42,6 +44,70 @@ func New(primeLength, g int, nn string) Group {
 	}
 }
 
+// NewWithGenerator function
+//
+// Constructor for groups with generator of arbitrary size, as used
+// by some proprietary deployments. See Validate before use.
+//
+// Params:
+// - primeLength {int}    prime length
+// - g           {string} generator (g) in hex
+// - nn          {string} large safe prime (N) in hex
+func NewWithGenerator(primeLength int, g, nn string) Group {
+	return Group{
+		PrimeLength: primeLength,
+		G:           v.New(g),
+		N:           v.New(nn),
+	}
+}
+
+// RFC5054 function: predefined group from RFC5054 appendix A
+//
+// Params:
+// - bits {int} prime length: 1024, 1536, 2048, 3072, 4096, 6144 or 8192
+//
+// Response:
+// - {Group}
+// - {error} if there is no group of given length
+func RFC5054(bits int) (Group, error) {
+	grp, ok := primes[bits]
+
+	if !ok {
+		return Group{}, fmt.Errorf("group: no RFC5054 group of %d bits", bits)
+	}
+
+	return grp, nil
+}
+
+// Validation errors
+var (
+	ErrInvalidPrime     = errors.New("group: N should be odd and greater than 3")
+	ErrInvalidGenerator = errors.New("group: g should be within (1, N-1)")
+)
+
+// Validate function: check group parameters are usable
+//
+// Doesn't check N is a safe prime, groups should come from a trusted source.
+//
+// Response:
+// - {error} nil if N is odd and greater than 3 and 1 < g < N-1
+func (grp Group) Validate() error {
+	nn := new(big.Int).SetBytes(grp.N.Bytes())
+
+	if nn.Bit(0) == 0 || nn.Cmp(big.NewInt(3)) <= 0 {
+		return ErrInvalidPrime
+	}
+
+	g := new(big.Int).SetBytes(grp.G.Bytes())
+	max := new(big.Int).Sub(nn, big.NewInt(1))
+
+	if g.Cmp(big.NewInt(1)) <= 0 || g.Cmp(max) >= 0 {
+		return ErrInvalidGenerator
+	}
+
+	return nil
+}
+
 // Predefined safe primes
 var primes = map[int]Group{
 	1024: New(
@@ -0,0 +1,107 @@
+package group_test
+
+import (
+	"math/big"
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	g "github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/value"
+)
+
+const nn = "EEAF0AB9ADB38DD69C33F80AFA8FC5E86072618775FF3C0B9EA2314C9C256576" +
+	"D674DF7496EA81D3383B4813D692C6E0E0D5D8E250B98BE48E495C1D6089DAD1" +
+	"5DC7D7B46154D6B6CE8EF4AD69B15D4982559B297BCF1885C529F566660E57EC" +
+	"68EDBC3C05726CC02FD4CBF4976EAA9AFD5138FE8376435B9FC61D2FC0EB06E3"
+
+func TestNewWithGenerator(t *testing.T) {
+	grp := g.NewWithGenerator(1024, "0123456789abcdef0123456789abcdef", nn)
+
+	if grp.G.Hex() != "0123456789abcdef0123456789abcdef" {
+		t.Error("generator should be equal")
+	}
+
+	if grp.Validate() != nil {
+		t.Error("large generator should be valid")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if g.New(1024, 2, nn).Validate() != nil {
+		t.Error("group should be valid")
+	}
+
+	cases := map[string]g.Group{
+		"g = 0":   g.New(1024, 0, nn),
+		"g = 1":   g.New(1024, 1, nn),
+		"g = N-1": g.NewWithGenerator(1024, nn[:len(nn)-1]+"2", nn),
+		"g = N":   g.NewWithGenerator(1024, nn, nn),
+		"g > N":   g.NewWithGenerator(1024, "01"+nn, nn),
+	}
+
+	for name, grp := range cases {
+		if grp.Validate() != g.ErrInvalidGenerator {
+			t.Errorf("%s should be invalid", name)
+		}
+	}
+}
+
+func TestValidatePrime(t *testing.T) {
+	if (g.Group{}).Validate() != g.ErrInvalidPrime {
+		t.Error("empty group should be invalid")
+	}
+
+	if g.New(1024, 2, nn[:len(nn)-1]+"4").Validate() != g.ErrInvalidPrime {
+		t.Error("even N should be invalid")
+	}
+}
+
+func TestRFC5054(t *testing.T) {
+	generators := map[int]int{1024: 2, 1536: 2, 2048: 2, 3072: 5, 4096: 5, 6144: 5, 8192: 19}
+
+	for bits, generator := range generators {
+		grp, err := g.RFC5054(bits)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if grp.PrimeLength != bits || grp.N.Int().BitLen() != bits || grp.G.Int().Int64() != int64(generator) {
+			t.Errorf("%d bits group should be equal to RFC5054", bits)
+		}
+
+		if grp.Validate() != nil {
+			t.Errorf("%d bits group should be valid", bits)
+		}
+
+		q := new(big.Int).Rsh(grp.N.Int(), 1)
+
+		if !grp.N.Int().ProbablyPrime(0) || !q.ProbablyPrime(0) {
+			t.Errorf("%d bits group N should be a safe prime", bits)
+		}
+	}
+
+	if grp, _ := g.RFC5054(1024); grp.N.Hex() != nn {
+		t.Error("1024 bits group should be equal to RFC5054 appendix B")
+	}
+}
+
+func TestRFC5054Unknown(t *testing.T) {
+	if _, err := g.RFC5054(512); err == nil {
+		t.Error("unknown group should be rejected")
+	}
+}
+
+func TestRFC5054AppendixB(t *testing.T) {
+	grp, _ := g.RFC5054(1024)
+	engine := e.NewGnuTLS(grp)
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+
+	if engine.K().Hex() != "7556aa045aef2cdd07abaf0f665c3e818913186f" {
+		t.Error("k should be equal to RFC5054 appendix B")
+	}
+
+	if engine.CalcV(engine.CalcX("password123", salt, "alice")).Hex() != "7e273de8696ffc4f4e337d05b4b375beb0dde1569e8fa00a9886d8129bada1f1822223ca1a605b530e379ba4729fdc59f105b4787e5186f5c671085a1447b52a48cf1970b4fb6f8400bbf4cebfbb168152e08ab5ea53d15c1aff87b2b9da6e04e058ad51cc72bfc9033b564e26480d78e955a5e29e7ab245db2be315e2099afb" {
+		t.Error("v should be equal to RFC5054 appendix B")
+	}
+}
@@ -0,0 +1,276 @@
+// Package policy allowed hash/group/KDF combinations
+//
+// Policy is evaluated when engine is constructed (Check) and on every
+// challenge (Challenge), so deployments running mixed legacy and modern
+// profiles can bound the legacy long tail:
+//
+//   pol := &policy.Policy{
+//     Hashes:         []crypto.Hash{crypto.SHA256, crypto.SHA512},
+//     MinGroupBits:   2048,
+//     KDFs:           []string{"pbkdf2"},
+//     MaxLegacyRatio: 0.05,
+//   }
+//
+//   if err := pol.Check(engine); err != nil { ... }
+//
+// Violations are returned as *Violation, which unwraps to one of ErrHash,
+// ErrGroup, ErrKDF, ErrMac or ErrLegacyRatio.
+package policy
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+)
+
+// Policy rules
+var (
+	ErrHash        = errors.New("policy: hash is not allowed")
+	ErrGroup       = errors.New("policy: group is too small")
+	ErrKDF         = errors.New("policy: kdf is not allowed")
+	ErrMac         = errors.New("policy: mac is unknown")
+	ErrLegacyRatio = errors.New("policy: legacy profile usage ratio exceeded")
+)
+
+// Violation struct: violated rule with offending value
+type Violation struct {
+	Rule  error
+	Value string
+}
+
+// Error function: error message
+//
+// Response:
+// - {string}
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s: %s", v.Rule.Error(), v.Value)
+}
+
+// Unwrap function: violated rule, see errors.Is
+//
+// Response:
+// - {error}
+func (v *Violation) Unwrap() error {
+	return v.Rule
+}
+
+// Legacy ratio accounting defaults, see Policy
+const (
+	DefaultLegacyWindow = 1000
+	DefaultMinSamples   = 100
+)
+
+// Policy struct: allowed engine configurations
+//
+// Provides:
+// Hashes         - allowed hashes, any if empty
+// MinGroupBits   - minimal prime length of group in bits, any if zero
+// KDFs           - allowed KDF names (see crypto.KDFParams), any if empty
+// MaxLegacyRatio - maximal share of challenges served by legacy profiles
+//                  (legacy KDF or legacy mac), unlimited if zero
+// LegacyWindow   - number of latest challenges the ratio is calculated over,
+//                  DefaultLegacyWindow if zero
+// MinSamples     - number of challenges in window before the ratio is enforced,
+//                  DefaultMinSamples if zero, at most LegacyWindow
+//
+// Sliding window lets the ratio recover once legacy users migrate, and
+// minimal sample size keeps the first legacy logins after a cold start
+// from being rejected.
+//
+// Hash and KDF rules require crypto engine to describe itself as
+// crypto.Standard does, other crypto engines violate them. Unknown mac
+// (see crypto.UnknownMac) is always a violation.
+// Policy is safe for concurrent use once configured.
+type Policy struct {
+	Hashes         []crypto.Hash
+	MinGroupBits   int
+	KDFs           []string
+	MaxLegacyRatio float64
+	LegacyWindow   int
+	MinSamples     int
+
+	mutex   sync.Mutex
+	samples []bool
+	next    int
+	legacy  int
+}
+
+// Check function: evaluate policy at engine construction
+//
+// Params:
+// - engine {engine.Engine}
+//
+// Response:
+// - {error} *Violation of first violated rule, nil if engine is allowed
+func (p *Policy) Check(engine e.Engine) error {
+	if bits := new(big.Int).SetBytes(engine.N.Bytes()).BitLen(); bits < p.MinGroupBits {
+		return &Violation{Rule: ErrGroup, Value: fmt.Sprintf("%d bits", bits)}
+	}
+
+	if len(p.Hashes) > 0 {
+		h, ok := engine.Crypto().(c.Hasher)
+
+		if !ok {
+			return &Violation{Rule: ErrHash, Value: "unknown"}
+		}
+
+		if !containsHash(p.Hashes, h.Hash()) {
+			return &Violation{Rule: ErrHash, Value: h.Hash().String()}
+		}
+	}
+
+	if len(p.KDFs) > 0 {
+		d, ok := engine.Crypto().(c.Describer)
+
+		if !ok {
+			return &Violation{Rule: ErrKDF, Value: "unknown"}
+		}
+
+		if name := d.KDFParams().Name; !containsString(p.KDFs, name) {
+			return &Violation{Rule: ErrKDF, Value: name}
+		}
+	}
+
+	if d, ok := engine.Crypto().(c.Describer); ok && d.MacMode() == c.UnknownMac {
+		return &Violation{Rule: ErrMac, Value: "unknown"}
+	}
+
+	return nil
+}
+
+// Challenge function: evaluate policy at challenge time
+//
+// Checks engine as Check does and accounts legacy profile usage.
+// Challenge is counted only if it's allowed.
+//
+// Params:
+// - engine {engine.Engine} engine of user's profile
+//
+// Response:
+// - {error} *Violation of first violated rule, nil if challenge is allowed
+func (p *Policy) Challenge(engine e.Engine) error {
+	if err := p.Check(engine); err != nil {
+		return err
+	}
+
+	legacy := isLegacy(engine)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if legacy && p.MaxLegacyRatio > 0 && len(p.samples) >= p.minSamples() {
+		count, total := p.legacy+1, len(p.samples)+1
+
+		if len(p.samples) == p.window() {
+			total--
+
+			if p.samples[p.next] {
+				count--
+			}
+		}
+
+		ratio := float64(count) / float64(total)
+
+		if ratio > p.MaxLegacyRatio {
+			return &Violation{Rule: ErrLegacyRatio, Value: fmt.Sprintf("%.4f", ratio)}
+		}
+	}
+
+	p.record(legacy)
+
+	return nil
+}
+
+// LegacyRatio function: share of allowed challenges in window served by legacy profiles
+//
+// Response:
+// - {float64}
+func (p *Policy) LegacyRatio() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.samples) == 0 {
+		return 0
+	}
+
+	return float64(p.legacy) / float64(len(p.samples))
+}
+
+// record function: add allowed challenge to window, evicting the oldest one
+func (p *Policy) record(legacy bool) {
+	if len(p.samples) < p.window() {
+		p.samples = append(p.samples, legacy)
+	} else {
+		if p.samples[p.next] {
+			p.legacy--
+		}
+
+		p.samples[p.next] = legacy
+		p.next = (p.next + 1) % len(p.samples)
+	}
+
+	if legacy {
+		p.legacy++
+	}
+}
+
+// window function: configured window size
+func (p *Policy) window() int {
+	if p.LegacyWindow > 0 {
+		return p.LegacyWindow
+	}
+
+	return DefaultLegacyWindow
+}
+
+// minSamples function: configured minimal sample size, at most window size
+func (p *Policy) minSamples() int {
+	size := p.MinSamples
+
+	if size <= 0 {
+		size = DefaultMinSamples
+	}
+
+	if size > p.window() {
+		return p.window()
+	}
+
+	return size
+}
+
+// isLegacy function: true if engine uses legacy KDF or legacy mac
+//
+// Crypto engines not describing themselves are counted as legacy.
+func isLegacy(engine e.Engine) bool {
+	impl := engine.Crypto()
+	mac := c.MacModeOf(impl)
+
+	return c.KDFParamsOf(impl).Name == "legacy" || mac == c.LegacyConcat || mac == c.UnknownMac
+}
+
+// containsHash function: true if hash is in hashes
+func containsHash(hashes []crypto.Hash, hash crypto.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsString function: true if str is in strs
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
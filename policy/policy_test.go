@@ -0,0 +1,145 @@
+package policy_test
+
+import (
+	hash "crypto"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/esrptest"
+	g "github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/policy"
+)
+
+var grp = g.New(1024, 2,
+	"EEAF0AB9ADB38DD69C33F80AFA8FC5E86072618775FF3C0B9EA2314C9C256576"+
+		"D674DF7496EA81D3383B4813D692C6E0E0D5D8E250B98BE48E495C1D6089DAD1"+
+		"5DC7D7B46154D6B6CE8EF4AD69B15D4982559B297BCF1885C529F566660E57EC"+
+		"68EDBC3C05726CC02FD4CBF4976EAA9AFD5138FE8376435B9FC61D2FC0EB06E3")
+
+var modern = e.New(c.NewStandard(hash.SHA256), grp)
+var legacy = e.New(c.NewStandardWithParams(hash.SHA256, c.StandardParams{LegacyKdf: true, Mac: c.LegacyConcat}), grp)
+
+func TestCheck(t *testing.T) {
+	pol := &policy.Policy{Hashes: []hash.Hash{hash.SHA256}, MinGroupBits: 1024, KDFs: []string{"pbkdf2"}}
+
+	if err := pol.Check(modern); err != nil {
+		t.Error(err)
+	}
+
+	cases := map[error]e.Engine{
+		policy.ErrHash:  e.New(c.NewStandard(hash.SHA1), grp),
+		policy.ErrKDF:   legacy,
+		policy.ErrGroup: e.New(c.NewStandard(hash.SHA256), g.New(512, 2, "EEAF0AB9ADB38DD69C33F80AFA8FC5E86072618775FF3C0B9EA2314C9C256576D674DF7496EA81D3383B4813D692C6E0E0D5D8E250B98BE48E495C1D6089DAD1")),
+	}
+
+	for rule, engine := range cases {
+		err := pol.Check(engine)
+
+		if !errors.Is(err, rule) {
+			t.Errorf("%v should be violated, got %v", rule, err)
+		}
+
+		var violation *policy.Violation
+
+		if !errors.As(err, &violation) {
+			t.Error("violation should be typed")
+		}
+	}
+}
+
+// opaque struct: crypto engine hiding description of wrapped one
+type opaque struct {
+	c.Crypto
+}
+
+func TestCheckUnknownMac(t *testing.T) {
+	undescribed := c.NewEntropy(opaque{c.NewStandard(hash.SHA256)}, rand.Reader)
+
+	if err := (&policy.Policy{}).Check(e.New(undescribed, grp)); !errors.Is(err, policy.ErrMac) {
+		t.Error("unknown mac should be violated")
+	}
+}
+
+func TestChallengeLegacyRatio(t *testing.T) {
+	pol := &policy.Policy{MaxLegacyRatio: 0.5, MinSamples: 2}
+
+	for i := 0; i < 2; i++ {
+		if err := pol.Challenge(modern); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if err := pol.Challenge(legacy); err != nil {
+		t.Error(err)
+	}
+
+	if err := pol.Challenge(legacy); err != nil {
+		t.Error(err)
+	}
+
+	if err := pol.Challenge(legacy); !errors.Is(err, policy.ErrLegacyRatio) {
+		t.Error("legacy ratio should be exceeded")
+	}
+
+	if pol.LegacyRatio() != 0.5 {
+		t.Error("legacy ratio should be equal")
+	}
+}
+
+func TestChallengeLegacyColdStart(t *testing.T) {
+	pol := &policy.Policy{MaxLegacyRatio: 0.05}
+
+	for i := 0; i < policy.DefaultMinSamples; i++ {
+		if err := pol.Challenge(legacy); err != nil {
+			t.Fatal("legacy challenges should be allowed until minimal sample size")
+		}
+	}
+
+	if err := pol.Challenge(legacy); !errors.Is(err, policy.ErrLegacyRatio) {
+		t.Error("legacy ratio should be enforced after minimal sample size")
+	}
+}
+
+func TestChallengeLegacyWindow(t *testing.T) {
+	pol := &policy.Policy{MaxLegacyRatio: 0.5, LegacyWindow: 4, MinSamples: 4}
+
+	for _, engine := range []e.Engine{modern, modern, legacy, legacy} {
+		if err := pol.Challenge(engine); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := pol.Challenge(legacy); !errors.Is(err, policy.ErrLegacyRatio) {
+		t.Error("legacy ratio should be exceeded")
+	}
+
+	for i := 0; i < 4; i++ {
+		pol.Challenge(modern)
+	}
+
+	if pol.LegacyRatio() != 0 {
+		t.Error("legacy challenges should leave the window")
+	}
+
+	if err := pol.Challenge(legacy); err != nil {
+		t.Error("legacy ratio should recover")
+	}
+}
+
+func TestChallengeLegacyWrapped(t *testing.T) {
+	legacyCrypto := c.NewStandardWithParams(hash.SHA256, c.StandardParams{LegacyKdf: true, Mac: c.LegacyConcat})
+
+	for name, wrapped := range map[string]c.Crypto{
+		"Trace":        c.NewTrace(legacyCrypto),
+		"FaultyCrypto": esrptest.NewFaultyCrypto(legacyCrypto),
+	} {
+		pol := &policy.Policy{MaxLegacyRatio: 0.5}
+
+		if err := pol.Challenge(e.New(wrapped, grp)); err != nil || pol.LegacyRatio() != 1 {
+			t.Errorf("legacy crypto wrapped in %s should be counted as legacy", name)
+		}
+	}
+}
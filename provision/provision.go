@@ -0,0 +1,123 @@
+// Package provision compact provisioning payload for device pairing
+//
+// Payload carries everything a device needs to register with the server:
+// username, group id, engine profile and one-time registration code.
+// Encoded form uses uppercase base32 only, so it fits QR alphanumeric mode:
+//
+//   ESRP1:<base32(version | group | profile | username | code | crc32)>
+//
+// Group is a prime length in bits (2 bytes), profile, username and code
+// are prefixed with 1 byte of length, crc32 covers all preceding bytes.
+package provision
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"strings"
+)
+
+const (
+	prefix  = "ESRP1:"
+	version = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ErrInvalidPayload is returned when payload can't be decoded
+var ErrInvalidPayload = errors.New("provision: invalid payload")
+
+// ErrFieldTooLong is returned when a field exceeds 255 bytes
+var ErrFieldTooLong = errors.New("provision: field is too long")
+
+// Payload struct: provisioning payload
+//
+// Provides:
+// Username - plain-text username in UTF8 string (I)
+// Group    - prime length of group in bits, e.g. 2048
+// Profile  - engine profile name
+// Code     - one-time registration code
+type Payload struct {
+	Username string
+	Group    int
+	Profile  string
+	Code     string
+}
+
+// Encode function: encode payload for QR display
+//
+// Params:
+// - payload {Payload}
+//
+// Response:
+// - {string}
+// - {error}
+func Encode(payload Payload) (string, error) {
+	if payload.Group < 0 || payload.Group > 0xffff {
+		return "", ErrInvalidPayload
+	}
+
+	buf := []byte{version, 0, 0}
+	binary.BigEndian.PutUint16(buf[1:], uint16(payload.Group))
+
+	for _, field := range []string{payload.Profile, payload.Username, payload.Code} {
+		if len(field) > 0xff {
+			return "", ErrFieldTooLong
+		}
+
+		buf = append(append(buf, byte(len(field))), field...)
+	}
+
+	sum := make([]byte, 4)
+	binary.BigEndian.PutUint32(sum, crc32.ChecksumIEEE(buf))
+
+	return prefix + encoding.EncodeToString(append(buf, sum...)), nil
+}
+
+// Decode function: decode scanned payload
+//
+// Params:
+// - str {string}
+//
+// Response:
+// - {Payload}
+// - {error}
+func Decode(str string) (Payload, error) {
+	if !strings.HasPrefix(str, prefix) {
+		return Payload{}, ErrInvalidPayload
+	}
+
+	buf, err := encoding.DecodeString(str[len(prefix):])
+
+	if err != nil || len(buf) < 3+3+4 || buf[0] != version {
+		return Payload{}, ErrInvalidPayload
+	}
+
+	body, sum := buf[:len(buf)-4], buf[len(buf)-4:]
+
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(sum) {
+		return Payload{}, ErrInvalidPayload
+	}
+
+	payload := Payload{Group: int(binary.BigEndian.Uint16(body[1:3]))}
+	rest := body[3:]
+	fields := make([]string, 3)
+
+	for i := range fields {
+		if len(rest) < 1 || len(rest) < 1+int(rest[0]) {
+			return Payload{}, ErrInvalidPayload
+		}
+
+		fields[i] = string(rest[1 : 1+int(rest[0])])
+		rest = rest[1+int(rest[0]):]
+	}
+
+	if len(rest) != 0 {
+		return Payload{}, ErrInvalidPayload
+	}
+
+	payload.Profile, payload.Username, payload.Code = fields[0], fields[1], fields[2]
+
+	return payload, nil
+}
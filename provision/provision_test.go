@@ -0,0 +1,70 @@
+package provision_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nsheremet/esrp/provision"
+)
+
+var payload = provision.Payload{
+	Username: "device-0042",
+	Group:    2048,
+	Profile:  "standard-sha256",
+	Code:     "7H2K-9QXP",
+}
+
+func TestProvisionRoundTrip(t *testing.T) {
+	str, err := provision.Encode(payload)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subj, err := provision.Decode(str)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if subj != payload {
+		t.Error("payload should be equal")
+	}
+}
+
+func TestProvisionAlphanumeric(t *testing.T) {
+	str, _ := provision.Encode(payload)
+	allowed := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+	for _, char := range str {
+		if !strings.ContainsRune(allowed, char) {
+			t.Errorf("%q is not allowed in QR alphanumeric mode", char)
+		}
+	}
+}
+
+func TestProvisionCorrupted(t *testing.T) {
+	str, _ := provision.Encode(payload)
+	corrupted := str[:10] + string(str[10]^1) + str[11:]
+
+	if _, err := provision.Decode(corrupted); err != provision.ErrInvalidPayload {
+		t.Error("should detect corruption")
+	}
+
+	if _, err := provision.Decode("ESRP1:"); err != provision.ErrInvalidPayload {
+		t.Error("should reject empty payload")
+	}
+
+	if _, err := provision.Decode("something else"); err != provision.ErrInvalidPayload {
+		t.Error("should reject unknown prefix")
+	}
+}
+
+func TestProvisionFieldTooLong(t *testing.T) {
+	long := payload
+	long.Username = strings.Repeat("a", 256)
+
+	if _, err := provision.Encode(long); err != provision.ErrFieldTooLong {
+		t.Error("should reject long field")
+	}
+}
@@ -0,0 +1,154 @@
+// Package registration client-side registration payload
+//
+// Client derives the verifier locally and sends the payload to the server,
+// so the server never sees the password and doesn't do any KDF work:
+//
+//   payload, err := registration.New(engine.Session(), "standard-sha256", username, password)
+//   body, err := registration.Encode(payload)
+//
+// Payload is encoded as JSON with hex encoded salt and verifier.
+package registration
+
+import (
+	"encoding/json"
+	"errors"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// SaltLength {int} length of generated salt in bytes
+const SaltLength = 16
+
+// ErrInvalidPayload is returned when payload can't be decoded
+var ErrInvalidPayload = errors.New("registration: invalid payload")
+
+// ErrNotConfigured is returned when engine has no crypto
+var ErrNotConfigured = errors.New("registration: engine is not configured")
+
+// KDF struct: password-based key derivation parameters used for x
+type KDF struct {
+	Name       string `json:"name"`
+	Hash       string `json:"hash,omitempty"`
+	Iterations int    `json:"iterations,omitempty"`
+	Memory     uint32 `json:"memory,omitempty"`
+	Threads    uint8  `json:"threads,omitempty"`
+}
+
+// Payload struct: registration payload
+//
+// Provides:
+// Username - plain-text username in UTF8 string (I)
+// Profile  - engine profile name
+// Group    - prime length of group in bits, e.g. 2048
+// KDF      - key derivation parameters, empty if crypto doesn't describe them
+// Salt     - random generated salt (s)
+// Verifier - password verifier (v)
+type Payload struct {
+	Username string  `json:"username"`
+	Profile  string  `json:"profile"`
+	Group    int     `json:"group"`
+	KDF      KDF     `json:"kdf"`
+	Salt     v.Value `json:"-"`
+	Verifier v.Value `json:"-"`
+}
+
+// wire struct: JSON representation of payload
+type wire struct {
+	Username string `json:"username"`
+	Profile  string `json:"profile"`
+	Group    int    `json:"group"`
+	KDF      KDF    `json:"kdf"`
+	Salt     string `json:"salt"`
+	Verifier string `json:"verifier"`
+}
+
+// New function: derive registration payload on the client
+//
+// Params:
+// - engine   {engine.Session} engine of selected profile, also used for login
+// - profile  {string} engine profile name, stored by the server as is
+// - username {string} plain-text username in UTF8 string
+// - password {string} plain-text password in UTF8 string
+//
+// Response:
+// - {Payload}
+// - {error}
+func New(engine e.Session, profile, username, password string) (Payload, error) {
+	crypto := engine.Crypto()
+
+	if crypto == nil {
+		return Payload{}, ErrNotConfigured
+	}
+
+	salt := crypto.Random(SaltLength)
+	payload := Payload{
+		Username: username,
+		Profile:  profile,
+		Group:    engine.PrimeLength(),
+		Salt:     salt,
+		Verifier: engine.CalcV(engine.CalcX(password, salt, username)),
+	}
+
+	if d, ok := crypto.(c.Describer); ok {
+		params := d.KDFParams()
+		payload.KDF = KDF{
+			Name:       params.Name,
+			Hash:       params.Hash,
+			Iterations: params.Iterations,
+			Memory:     params.Memory,
+			Threads:    params.Threads,
+		}
+	}
+
+	return payload, nil
+}
+
+// Encode function: payload as JSON body
+//
+// Params:
+// - payload {Payload}
+//
+// Response:
+// - {[]byte}
+// - {error}
+func Encode(payload Payload) ([]byte, error) {
+	return json.Marshal(wire{
+		Username: payload.Username,
+		Profile:  payload.Profile,
+		Group:    payload.Group,
+		KDF:      payload.KDF,
+		Salt:     payload.Salt.Hex(),
+		Verifier: payload.Verifier.Hex(),
+	})
+}
+
+// Decode function: payload from JSON body
+//
+// Params:
+// - data {[]byte}
+//
+// Response:
+// - {Payload}
+// - {error} ErrInvalidPayload if any field is missing or malformed
+func Decode(data []byte) (Payload, error) {
+	var w wire
+
+	if err := json.Unmarshal(data, &w); err != nil {
+		return Payload{}, ErrInvalidPayload
+	}
+
+	if w.Username == "" || w.Profile == "" || w.Group <= 0 || !v.IsHex(w.Salt) || !v.IsHex(w.Verifier) {
+		return Payload{}, ErrInvalidPayload
+	}
+
+	return Payload{
+		Username: w.Username,
+		Profile:  w.Profile,
+		Group:    w.Group,
+		KDF:      w.KDF,
+		Salt:     v.New(w.Salt),
+		Verifier: v.New(w.Verifier),
+	}, nil
+}
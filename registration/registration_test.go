@@ -0,0 +1,114 @@
+package registration_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	"github.com/nsheremet/esrp"
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	g "github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/registration"
+	"github.com/nsheremet/esrp/value"
+)
+
+var grp = g.New(1024, 2,
+	"EEAF0AB9ADB38DD69C33F80AFA8FC5E86072618775FF3C0B9EA2314C9C256576"+
+		"D674DF7496EA81D3383B4813D692C6E0E0D5D8E250B98BE48E495C1D6089DAD1"+
+		"5DC7D7B46154D6B6CE8EF4AD69B15D4982559B297BCF1885C529F566660E57EC"+
+		"68EDBC3C05726CC02FD4CBF4976EAA9AFD5138FE8376435B9FC61D2FC0EB06E3")
+
+var engine = e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)}.Session()
+
+func TestNew(t *testing.T) {
+	payload, err := registration.New(engine, "standard-sha256", "alice", "password123")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Group != 1024 || len(payload.Salt.Bytes()) != registration.SaltLength {
+		t.Error("group and salt should be set")
+	}
+
+	if payload.KDF != (registration.KDF{Name: "pbkdf2", Hash: "SHA-256", Iterations: 20000}) {
+		t.Error("kdf should be described")
+	}
+
+	if payload.Verifier.Hex() != engine.CalcV(engine.CalcX("password123", payload.Salt, "alice")).Hex() {
+		t.Error("verifier should be equal")
+	}
+}
+
+func TestNewNotConfigured(t *testing.T) {
+	if _, err := registration.New(e.Standard{}.Session(), "standard-sha256", "alice", "password123"); err != registration.ErrNotConfigured {
+		t.Error("should require configured engine")
+	}
+}
+
+func TestNewHandshake(t *testing.T) {
+	accountKey := e.NewAccountKey(e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)}, value.New("a3f1c0b2d4e5f60718293a4b5c6d7e8f"), "alice")
+
+	engines := map[string]e.Session{
+		"RFC5054":    e.NewRFC5054(grp),
+		"AccountKey": accountKey.Session(),
+	}
+
+	for name, engine := range engines {
+		payload, err := registration.New(engine, name, "alice", "password123")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		client := esrp.NewClient(engine, "alice", "password123")
+		server := esrp.NewServer(engine)
+
+		aa, _ := client.StartAuthentication()
+		bb, _ := server.CreateChallenge(payload.Username, payload.Verifier, payload.Salt)
+		mm, _ := client.ProcessChallenge(payload.Salt, bb)
+		mm2, err := server.VerifyClientProof(aa, mm)
+
+		if err != nil || client.VerifyServerProof(mm2) != nil {
+			t.Errorf("handshake should succeed against %s verifier", name)
+		}
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	payload, _ := registration.New(engine, "standard-sha256", "alice", "password123")
+	data, err := registration.Encode(payload)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := registration.Decode(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Username != "alice" || decoded.Profile != "standard-sha256" || decoded.KDF != payload.KDF {
+		t.Error("fields should be equal")
+	}
+
+	if decoded.Salt.Hex() != payload.Salt.Hex() || decoded.Verifier.Hex() != payload.Verifier.Hex() {
+		t.Error("salt and verifier should be equal")
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	cases := []string{
+		``,
+		`{}`,
+		`{"username":"alice","profile":"p","group":1024,"salt":"zz","verifier":"01"}`,
+		`{"username":"alice","profile":"p","group":1024,"salt":"01"}`,
+	}
+
+	for _, data := range cases {
+		if _, err := registration.Decode([]byte(data)); err != registration.ErrInvalidPayload {
+			t.Errorf("%s should be invalid", data)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package esrp
+
+import (
+	"sync"
+
+	e "github.com/nsheremet/esrp/engine"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// serverState type: server handshake step
+type serverState int
+
+const (
+	serverNew serverState = iota
+	serverChallenged
+	serverVerified
+	serverFailed
+)
+
+// Server struct: server side of SRP handshake
+//
+// Holds verifier state of single handshake:
+//
+//   server := esrp.NewServer(engine.Session())
+//   bb, _ := server.CreateChallenge(username, verifier, salt) // send s, B to the client
+//   mm2, err := server.VerifyClientProof(aa, mm)              // send M2 to the client
+//   kk, _ := server.SessionKey()
+//
+// Any failure makes the server unusable, a new one is required to retry.
+// Server is not safe for concurrent use.
+type Server struct {
+	engine   e.Session
+	state    serverState
+	username string
+	val      v.Value
+	salt     v.Value
+	b        v.Value
+	bb       v.Value
+	kk       v.Value
+}
+
+// serverPool {sync.Pool} pool of reusable servers, see AcquireServer
+var serverPool = sync.Pool{New: func() interface{} { return new(Server) }}
+
+// NewServer function Constructor
+//
+// Params:
+// - engine {engine.Session} engine shared with the client
+//
+// Response:
+// - {*Server}
+func NewServer(engine e.Session) *Server {
+	return &Server{engine: engine}
+}
+
+// AcquireServer function: server from the pool
+//
+// Cuts allocation churn for gateways doing many handshakes. Server should be
+// returned to the pool with Reset once handshake is over.
+//
+// Params:
+// - engine {engine.Session} engine shared with the client
+//
+// Response:
+// - {*Server}
+func AcquireServer(engine e.Session) *Server {
+	s := serverPool.Get().(*Server)
+	s.engine = engine
+
+	return s
+}
+
+// Reset function: wipe secrets and return server to the pool
+//
+// Server must not be used afterwards, values returned earlier
+// (including session key) stay valid. Verifier and salt passed
+// to CreateChallenge are left intact.
+func (s *Server) Reset() {
+	s.b.Wipe()
+	s.kk.Wipe()
+	*s = Server{}
+	serverPool.Put(s)
+}
+
+// CreateChallenge function: generate server ephemeral values
+//
+// Params:
+// - username {string}     plain-text username in UTF8 string (I)
+// - verifier {esrp.Value} stored password verifier (v)
+// - salt     {esrp.Value} stored user's salt (s)
+//
+// Response:
+// - {esrp.Value} public server ephemeral value (B)
+// - {error}
+func (s *Server) CreateChallenge(username string, verifier, salt v.Value) (v.Value, error) {
+	if s.state != serverNew {
+		return v.Value{}, s.fail(ErrInvalidState)
+	}
+
+	s.username = username
+	s.val = verifier
+	s.salt = salt
+	s.b = s.engine.Crypto().Random(EphemeralLength)
+	s.bb = s.engine.CalcB(s.b, s.val)
+	s.state = serverChallenged
+
+	return s.bb, nil
+}
+
+// VerifyClientProof function: check client validation message
+//
+// Aborts if A mod N == 0 or u == 0. Premaster secret (S) and
+// secret ephemeral value (b) are wiped afterwards.
+//
+// Params:
+// - aa {esrp.Value} public client ephemeral value (A)
+// - mm {esrp.Value} validation message (M)
+//
+// Response:
+// - {esrp.Value} response validation message (M2)
+// - {error} ErrInvalidProof if client doesn't know the password
+func (s *Server) VerifyClientProof(aa, mm v.Value) (v.Value, error) {
+	if s.state != serverChallenged {
+		return v.Value{}, s.fail(ErrInvalidState)
+	}
+
+	if !s.engine.IsValidPublic(aa) {
+		return v.Value{}, s.fail(ErrInvalidPublic)
+	}
+
+	u := s.engine.CalcU(aa, s.bb)
+
+	if u.Int().Sign() == 0 {
+		return v.Value{}, s.fail(ErrInvalidPublic)
+	}
+
+	ss := s.engine.CalcServerS(aa, s.b, s.val, u)
+	kk := s.engine.CalcK(ss)
+	expected := s.engine.CalcM(kk, aa, s.bb, ss, s.salt, s.username)
+
+	if !s.engine.Crypto().SecureCompare(expected, mm) {
+		ss.Wipe()
+		kk.Wipe()
+
+		return v.Value{}, s.fail(ErrInvalidProof)
+	}
+
+	mm2 := s.engine.CalcM2(kk, aa, mm, ss)
+	ss.Wipe()
+	s.b.Wipe()
+	s.kk, s.b = kk, v.Value{}
+	s.state = serverVerified
+
+	return mm2, nil
+}
+
+// SessionKey function: private session key (K) of verified handshake
+//
+// Response:
+// - {esrp.Value} private session key (K)
+// - {error} ErrInvalidState until client proof is verified
+func (s *Server) SessionKey() (v.Value, error) {
+	if s.state != serverVerified {
+		return v.Value{}, ErrInvalidState
+	}
+
+	return v.New(s.kk.Bytes()), nil
+}
+
+// fail function: wipe secrets and make server unusable
+//
+// Verifier belongs to the caller, so it is dropped, not wiped.
+func (s *Server) fail(err error) error {
+	s.state = serverFailed
+	s.b.Wipe()
+	s.kk.Wipe()
+	s.b, s.val, s.kk = v.Value{}, v.Value{}, v.Value{}
+
+	return err
+}
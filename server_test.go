@@ -0,0 +1,249 @@
+package esrp_test
+
+import (
+	hash "crypto"
+	"testing"
+
+	"github.com/nsheremet/esrp"
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/value"
+)
+
+func TestServerHandshake(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	verifier := handshakeEngine.CalcV(handshakeEngine.CalcX("password123", salt, "alice"))
+	client := esrp.NewClient(handshakeEngine, "alice", "password123")
+	server := esrp.NewServer(handshakeEngine)
+
+	aa, _ := client.StartAuthentication()
+	bb, err := server.CreateChallenge("alice", verifier, salt)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mm, _ := client.ProcessChallenge(salt, bb)
+	mm2, err := server.VerifyClientProof(aa, mm)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.VerifyServerProof(mm2); err != nil {
+		t.Fatal(err)
+	}
+
+	clientKey, _ := client.SessionKey()
+	serverKey, err := server.SessionKey()
+
+	if err != nil || clientKey.Hex() != serverKey.Hex() {
+		t.Error("session keys should be equal")
+	}
+}
+
+func TestServerWrongPassword(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	verifier := handshakeEngine.CalcV(handshakeEngine.CalcX("password123", salt, "alice"))
+	client := esrp.NewClient(handshakeEngine, "alice", "password124")
+	server := esrp.NewServer(handshakeEngine)
+
+	aa, _ := client.StartAuthentication()
+	bb, _ := server.CreateChallenge("alice", verifier, salt)
+	mm, _ := client.ProcessChallenge(salt, bb)
+
+	if _, err := server.VerifyClientProof(aa, mm); err != esrp.ErrInvalidProof {
+		t.Error("client proof should be rejected")
+	}
+
+	if _, err := server.SessionKey(); err != esrp.ErrInvalidState {
+		t.Error("session key should be unavailable")
+	}
+}
+
+func TestServerInvalidPublic(t *testing.T) {
+	salt := value.New("0451")
+	verifier := handshakeEngine.CalcV(handshakeEngine.CalcX("password123", salt, "alice"))
+
+	for _, aa := range []value.Value{value.New("00"), grp.N} {
+		server := esrp.NewServer(handshakeEngine)
+		server.CreateChallenge("alice", verifier, salt)
+
+		if _, err := server.VerifyClientProof(aa, value.New("00")); err != esrp.ErrInvalidPublic {
+			t.Error("A mod N == 0 should be rejected")
+		}
+	}
+}
+
+func TestServerOrder(t *testing.T) {
+	server := esrp.NewServer(handshakeEngine)
+
+	if _, err := server.VerifyClientProof(value.New("02"), value.New("00")); err != esrp.ErrInvalidState {
+		t.Error("proof before challenge should be rejected")
+	}
+
+	if _, err := server.CreateChallenge("alice", value.New("02"), value.New("0451")); err != esrp.ErrInvalidState {
+		t.Error("failed server should be unusable")
+	}
+}
+
+func TestHandshakeReset(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	verifier := handshakeEngine.CalcV(handshakeEngine.CalcX("password123", salt, "alice"))
+
+	for i := 0; i < 3; i++ {
+		client := esrp.AcquireClient(handshakeEngine, "alice", "password123")
+		server := esrp.AcquireServer(handshakeEngine)
+
+		aa, _ := client.StartAuthentication()
+		bb, _ := server.CreateChallenge("alice", verifier, salt)
+		mm, _ := client.ProcessChallenge(salt, bb)
+		mm2, err := server.VerifyClientProof(aa, mm)
+
+		if err != nil || client.VerifyServerProof(mm2) != nil {
+			t.Fatal("handshake should succeed with pooled objects")
+		}
+
+		clientKey, _ := client.SessionKey()
+		serverKey, _ := server.SessionKey()
+		expected := serverKey.Hex()
+
+		client.Reset()
+		server.Reset()
+
+		if clientKey.Hex() != expected || value.New(serverKey.Bytes()).Hex() != expected {
+			t.Error("session keys should stay valid after reset")
+		}
+	}
+
+	if value.New(verifier.Bytes()).Hex() != handshakeEngine.CalcV(handshakeEngine.CalcX("password123", salt, "alice")).Hex() {
+		t.Error("verifier should be left intact")
+	}
+}
+
+func TestServerEngines(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	accountKey := e.NewAccountKey(e.Standard{Engine: e.New(c.NewStandard(hash.SHA256), grp)}, value.New("a3f1c0b2d4e5f60718293a4b5c6d7e8f"), "alice")
+
+	engines := map[string]e.Session{
+		"RFC5054":    e.NewRFC5054(grp),
+		"SrpRb":      e.NewSrpRb(grp),
+		"AccountKey": accountKey.Session(),
+	}
+
+	for name, engine := range engines {
+		client := esrp.NewClient(engine, "alice", "password123")
+		server := esrp.NewServer(engine)
+
+		aa, _ := client.StartAuthentication()
+		bb, _ := server.CreateChallenge("alice", engine.CalcV(engine.CalcX("password123", salt, "alice")), salt)
+		mm, _ := client.ProcessChallenge(salt, bb)
+		mm2, err := server.VerifyClientProof(aa, mm)
+
+		if err != nil || client.VerifyServerProof(mm2) != nil {
+			t.Errorf("handshake should succeed with %s engine", name)
+		}
+	}
+}
+
+// recordingCrypto struct: crypto engine keeping generated random values
+type recordingCrypto struct {
+	c.Crypto
+	secrets *[][]byte
+}
+
+func (r recordingCrypto) Random(bytesLength int) value.Value {
+	random := r.Crypto.Random(bytesLength)
+	*r.secrets = append(*r.secrets, random.Bytes())
+
+	return random
+}
+
+// recordingEngine struct: engine keeping backing slices of secrets (a, b, x, S, K)
+type recordingEngine struct {
+	e.Session
+	secrets *[][]byte
+}
+
+func newRecordingEngine() recordingEngine {
+	return recordingEngine{Session: handshakeEngine, secrets: &[][]byte{}}
+}
+
+func (r recordingEngine) record(secret value.Value) value.Value {
+	*r.secrets = append(*r.secrets, secret.Bytes())
+	return secret
+}
+
+func (r recordingEngine) Crypto() c.Crypto {
+	return recordingCrypto{Crypto: r.Session.Crypto(), secrets: r.secrets}
+}
+
+func (r recordingEngine) CalcX(password string, salt value.Value, username string) value.Value {
+	return r.record(r.Session.CalcX(password, salt, username))
+}
+
+func (r recordingEngine) CalcClientS(bb, a, x, u value.Value) value.Value {
+	return r.record(r.Session.CalcClientS(bb, a, x, u))
+}
+
+func (r recordingEngine) CalcServerS(aa, b, val, u value.Value) value.Value {
+	return r.record(r.Session.CalcServerS(aa, b, val, u))
+}
+
+func (r recordingEngine) CalcK(ss value.Value) value.Value {
+	return r.record(r.Session.CalcK(ss))
+}
+
+// wiped function: true if every recorded secret is zeroed
+func (r recordingEngine) wiped() bool {
+	for _, secret := range *r.secrets {
+		for _, b := range secret {
+			if b != 0 {
+				return false
+			}
+		}
+	}
+
+	return len(*r.secrets) > 0
+}
+
+func TestHandshakeWipe(t *testing.T) {
+	salt := value.New("beb25379d1a8581eb5a727673a2441ee")
+	verifier := handshakeEngine.CalcV(handshakeEngine.CalcX("password123", salt, "alice"))
+
+	for _, password := range []string{"password123", "password124"} {
+		clientEngine, serverEngine := newRecordingEngine(), newRecordingEngine()
+		client := esrp.AcquireClient(clientEngine, "alice", password)
+		server := esrp.AcquireServer(serverEngine)
+
+		aa, _ := client.StartAuthentication()
+		bb, _ := server.CreateChallenge("alice", verifier, salt)
+		mm, _ := client.ProcessChallenge(salt, bb)
+		mm2, err := server.VerifyClientProof(aa, mm)
+
+		if err != nil {
+			if !serverEngine.wiped() {
+				t.Error("server secrets should be wiped on failure")
+			}
+
+			client.VerifyServerProof(mm2)
+
+			if !clientEngine.wiped() {
+				t.Error("client secrets should be wiped on failure")
+			}
+
+			continue
+		}
+
+		if client.VerifyServerProof(mm2) != nil {
+			t.Fatal("handshake should succeed")
+		}
+
+		client.Reset()
+		server.Reset()
+
+		if !clientEngine.wiped() || !serverEngine.wiped() {
+			t.Error("secrets should be wiped on reset")
+		}
+	}
+}
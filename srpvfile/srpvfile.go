@@ -0,0 +1,199 @@
+// Package srpvfile OpenSSL SRP verifier file format
+//
+// Reads and writes verifier files managed by "openssl srp" (-srpvfile)
+// and consumed by OpenSSL-based TLS-SRP servers (SRP_VBASE_init).
+//
+// Each line is a record of 6 tab-separated fields:
+//
+//   type | verifier | salt | username | group id | info
+//
+// Type "V" is valid user, "R" is revoked user, "v" is user being modified,
+// "I" is an index entry describing a group: verifier field holds N,
+// salt field holds g, username field holds the group id.
+// Values are encoded with OpenSSL's SRP base64 (see Encode).
+//
+// OpenSSL computes verifiers as RFC5054 does (see engine.GnuTLS):
+//
+//   x = SHA1(s | SHA1(I | ":" | p))
+//   v = g^x
+package srpvfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Record types
+const (
+	Valid    = "V"
+	Revoked  = "R"
+	Modified = "v"
+	Index    = "I"
+)
+
+// b64table {string} OpenSSL's SRP base64 alphabet
+const b64table = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz./"
+
+// Record struct: single verifier file entry
+//
+// Provides:
+// Type     - record type (Valid, Revoked, Modified, Index)
+// Verifier - password verifier (v), or N for Index records
+// Salt     - salt (s), or g for Index records
+// Username - username (I), or group id for Index records
+// Group    - group id, e.g. "1024" for RFC5054 1024-bit group
+// Info     - optional user info
+type Record struct {
+	Type     string
+	Verifier v.Value
+	Salt     v.Value
+	Username string
+	Group    string
+	Info     string
+}
+
+// Read function: parse verifier file
+//
+// Empty lines and lines starting with '#' are skipped.
+//
+// Params:
+// - r {io.Reader}
+//
+// Response:
+// - {[]Record}
+// - {error}
+func Read(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+
+		if text == "" || text[0] == '#' {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("srpvfile: line %d: expected 6 fields, got %d", line, len(fields))
+		}
+
+		verifier, err := Decode(fields[1])
+
+		if err != nil {
+			return nil, fmt.Errorf("srpvfile: line %d: %s", line, err)
+		}
+
+		salt, err := Decode(fields[2])
+
+		if err != nil {
+			return nil, fmt.Errorf("srpvfile: line %d: %s", line, err)
+		}
+
+		records = append(records, Record{
+			Type:     fields[0],
+			Verifier: verifier,
+			Salt:     salt,
+			Username: fields[3],
+			Group:    fields[4],
+			Info:     fields[5],
+		})
+	}
+
+	return records, scanner.Err()
+}
+
+// Write function: write records in verifier file format
+//
+// Params:
+// - w       {io.Writer}
+// - records {[]Record}
+//
+// Response:
+// - {error}
+func Write(w io.Writer, records []Record) error {
+	for _, record := range records {
+		fields := []string{
+			record.Type,
+			Encode(record.Verifier),
+			Encode(record.Salt),
+			record.Username,
+			record.Group,
+			record.Info,
+		}
+
+		for _, field := range fields {
+			if strings.ContainsAny(field, "\t\n") {
+				return fmt.Errorf("srpvfile: field %q contains tab or newline", field)
+			}
+		}
+
+		if _, err := io.WriteString(w, strings.Join(fields, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Encode function: OpenSSL's SRP base64 encoding (t_tob64)
+//
+// Unlike RFC4648 base64, value is treated as big-endian number:
+// it's written in base 64 with own alphabet, without leading zeros and padding.
+//
+// Params:
+// - value {v.Value}
+//
+// Response:
+// - {string}
+func Encode(value v.Value) string {
+	num := new(big.Int).SetBytes(value.Bytes())
+	mask := big.NewInt(63)
+	digit := new(big.Int)
+	var res []byte
+
+	for num.Sign() > 0 {
+		res = append(res, b64table[digit.And(num, mask).Int64()])
+		num.Rsh(num, 6)
+	}
+
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+
+	return string(res)
+}
+
+// Decode function: OpenSSL's SRP base64 decoding (t_fromb64)
+//
+// Leading zero bytes are not preserved, as in OpenSSL.
+//
+// Params:
+// - str {string}
+//
+// Response:
+// - {v.Value}
+// - {error}
+func Decode(str string) (v.Value, error) {
+	num := new(big.Int)
+
+	for i := 0; i < len(str); i++ {
+		pos := strings.IndexByte(b64table, str[i])
+
+		if pos < 0 {
+			return v.Value{}, fmt.Errorf("srpvfile: invalid base64 character %q", str[i])
+		}
+
+		num.Lsh(num, 6)
+		num.Or(num, big.NewInt(int64(pos)))
+	}
+
+	return v.New(num), nil
+}
@@ -0,0 +1,99 @@
+package srpvfile_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	e "github.com/nsheremet/esrp/engine"
+	"github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/srpvfile"
+	"github.com/nsheremet/esrp/value"
+)
+
+// testdata/openssl.srpv is produced by:
+//
+//   openssl srp -srpvfile openssl.srpv -add -gn 1024 -passout pass:password123 alice
+//   openssl srp -srpvfile openssl.srpv -add -gn 1536 -userinfo "some info" -passout pass:secret bob
+var n1024 = "EEAF0AB9ADB38DD69C33F80AFA8FC5E86072618775FF3C0B9EA2314C9C256576" +
+	"D674DF7496EA81D3383B4813D692C6E0E0D5D8E250B98BE48E495C1D6089DAD1" +
+	"5DC7D7B46154D6B6CE8EF4AD69B15D4982559B297BCF1885C529F566660E57EC" +
+	"68EDBC3C05726CC02FD4CBF4976EAA9AFD5138FE8376435B9FC61D2FC0EB06E3"
+
+func TestSrpvfileRead(t *testing.T) {
+	data, _ := ioutil.ReadFile("testdata/openssl.srpv")
+	records, err := srpvfile.Read(bytes.NewReader(data))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 2 {
+		t.Fatal("should read all records")
+	}
+
+	alice := records[0]
+
+	if alice.Type != srpvfile.Valid || alice.Username != "alice" || alice.Group != "1024" || alice.Info != "" {
+		t.Error("record fields should be equal")
+	}
+
+	if records[1].Info != "some info" {
+		t.Error("info should be equal")
+	}
+
+	engine := e.NewGnuTLS(group.New(1024, 2, n1024))
+	val := engine.CalcV(engine.CalcX("password123", alice.Salt, "alice"))
+
+	if val.Hex() != alice.Verifier.Hex() {
+		t.Error("verifier should be equal to OpenSSL's one")
+	}
+}
+
+func TestSrpvfileWrite(t *testing.T) {
+	data, _ := ioutil.ReadFile("testdata/openssl.srpv")
+	records, _ := srpvfile.Read(bytes.NewReader(data))
+
+	var buf bytes.Buffer
+
+	if err := srpvfile.Write(&buf, records); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Error("written file should be equal to OpenSSL's one")
+	}
+}
+
+func TestSrpvfileWriteRejectsTabs(t *testing.T) {
+	records := []srpvfile.Record{{Type: srpvfile.Valid, Username: "al\tice"}}
+
+	if err := srpvfile.Write(&bytes.Buffer{}, records); err == nil {
+		t.Error("should reject tab in field")
+	}
+}
+
+func TestSrpvfileReadInvalid(t *testing.T) {
+	if _, err := srpvfile.Read(strings.NewReader("V\tabc\n")); err == nil {
+		t.Error("should reject wrong number of fields")
+	}
+
+	if _, err := srpvfile.Read(strings.NewReader("V\t!!\tabc\talice\t1024\t\n")); err == nil {
+		t.Error("should reject invalid base64")
+	}
+}
+
+func TestSrpvfileEncode(t *testing.T) {
+	subj := value.New("0102")
+
+	if srpvfile.Encode(subj) != "42" {
+		t.Error("encoded value should be equal")
+	}
+
+	decoded, _ := srpvfile.Decode("42")
+
+	if decoded.Hex() != "0102" {
+		t.Error("decoded value should be equal")
+	}
+}
@@ -28,26 +28,59 @@ type Value struct {
 // Response:
 // - value {Value} value with hex attribute
 func New(arg interface{}) (value Value) {
-	if v, ok := arg.(string); ok {
-		value.hex = v
-	} else if v, ok := arg.([]byte); ok {
-		value.hex = fmt.Sprintf("%x", string(v))
-	} else if v, ok := arg.(*big.Int); ok {
-		value.hex = fmt.Sprintf("%x", string(v.Bytes()))
-	}
+	switch v := arg.(type) {
+	case string:
+		buff, err := hex.DecodeString(v)
 
-	buff, err := hex.DecodeString(value.hex)
+		if err != nil {
+			log.Fatal(err)
+		}
 
-	if err != nil {
-		log.Fatal(err)
+		value.hex = v
+		value.bytes = buff
+	case []byte:
+		value.bytes = append(make([]byte, 0, len(v)), v...)
+		value.hex = encode(value.bytes)
+	case *big.Int:
+		value.bytes = v.Bytes()
+		value.hex = encode(value.bytes)
+	default:
+		value.bytes = []byte{}
 	}
 
-	value.bytes = buff
-	value.int = new(big.Int).SetBytes([]byte(value.bytes))
+	value.int = new(big.Int).SetBytes(value.bytes)
 
 	return value
 }
 
+// IsHex function: true if str is non-empty hex
+//
+// Params:
+// - str {string}
+//
+// Response:
+// - {bool}
+func IsHex(str string) bool {
+	_, err := hex.DecodeString(str)
+	return str != "" && err == nil
+}
+
+// encode function: hex encoding into preallocated buffer
+//
+// Avoids fmt formatting, which dominates construction of small values.
+//
+// Params:
+// - bytes {[]byte}
+//
+// Response:
+// - {string} hex in UTF-8
+func encode(bytes []byte) string {
+	buff := make([]byte, hex.EncodedLen(len(bytes)))
+	hex.Encode(buff, bytes)
+
+	return string(buff)
+}
+
 // Bytes function
 //
 // Represent value as byte array
@@ -68,6 +101,54 @@ func (v Value) Hex() string {
 	return v.hex
 }
 
+// AppendBytes function
+//
+// Appends byte representation to dst, doesn't allocate if dst has
+// enough capacity
+//
+// Params:
+// - dst {[]byte}
+//
+// Response:
+// - {[]byte} extended dst
+func (v Value) AppendBytes(dst []byte) []byte {
+	return append(dst, v.bytes...)
+}
+
+// AppendPadded function
+//
+// Appends byte representation left-padded with zeros up to length bytes
+// (PAD() primitive), doesn't allocate if dst has enough capacity.
+// Values which are already long enough are appended as is.
+//
+// Params:
+// - dst    {[]byte}
+// - length {int} desired length in bytes
+//
+// Response:
+// - {[]byte} extended dst
+func (v Value) AppendPadded(dst []byte, length int) []byte {
+	for i := len(v.bytes); i < length; i++ {
+		dst = append(dst, 0)
+	}
+
+	return append(dst, v.bytes...)
+}
+
+// AppendHex function
+//
+// Appends hex representation to dst, doesn't allocate if dst has
+// enough capacity
+//
+// Params:
+// - dst {[]byte}
+//
+// Response:
+// - {[]byte} extended dst
+func (v Value) AppendHex(dst []byte) []byte {
+	return append(dst, v.hex...)
+}
+
 // Int function
 //
 // Represent value as big.Int
@@ -78,6 +159,27 @@ func (v Value) Int() *big.Int {
 	return v.int
 }
 
+// Wipe function
+//
+// Overwrites byte and integer representations with zeros, so secrets
+// don't linger in memory. Hex representation is an immutable string
+// and can't be wiped. Value (and its copies) must not be used afterwards.
+func (v Value) Wipe() {
+	for i := range v.bytes {
+		v.bytes[i] = 0
+	}
+
+	if v.int != nil {
+		words := v.int.Bits()
+
+		for i := range words {
+			words[i] = 0
+		}
+
+		v.int.SetInt64(0)
+	}
+}
+
 // Bin function
 //
 // Returns binary string that use the \xNN notation
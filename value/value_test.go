@@ -62,3 +62,121 @@ func TestValueCreatingToInt(t *testing.T) {
 		t.Error("bytes should be equal")
 	}
 }
+
+func TestValueCreatingToUnknown(t *testing.T) {
+	value := v.New(42)
+
+	if value.Hex() != "" || len(value.Bytes()) != 0 || value.Int().Sign() != 0 {
+		t.Error("value should be empty")
+	}
+}
+
+func TestIsHex(t *testing.T) {
+	if !v.IsHex(hex) {
+		t.Error("hex should be valid")
+	}
+
+	for _, str := range []string{"", "034", "zz"} {
+		if v.IsHex(str) {
+			t.Errorf("%q should be invalid", str)
+		}
+	}
+}
+
+func TestValueCreatingToBytesCopies(t *testing.T) {
+	src := []byte{3, 75}
+	value := v.New(src)
+	src[0] = 0
+
+	if value.Hex() != "034b" || value.Bytes()[0] != 3 {
+		t.Error("value should not share bytes with argument")
+	}
+}
+
+var benchBytes = b.Repeat([]byte{0xab}, 256)
+var benchInt = new(big.Int).SetBytes(benchBytes)
+var benchHex = h.EncodeToString(benchBytes)
+
+func TestValueAppend(t *testing.T) {
+	value := v.New(hex)
+	dst := []byte{0xff}
+
+	if !b.Equal(value.AppendBytes(dst), append([]byte{0xff}, bytes...)) {
+		t.Error("bytes should be appended")
+	}
+
+	if !b.Equal(value.AppendPadded(dst, 8), append([]byte{0xff, 0, 0, 0}, bytes...)) {
+		t.Error("padded bytes should be appended")
+	}
+
+	if !b.Equal(value.AppendPadded(dst, 2), append([]byte{0xff}, bytes...)) {
+		t.Error("long value should be appended as is")
+	}
+
+	if string(value.AppendHex(dst)) != "\xff"+hex {
+		t.Error("hex should be appended")
+	}
+}
+
+func TestValueAppendNoAllocs(t *testing.T) {
+	value := v.New(hex)
+	dst := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		dst = value.AppendPadded(dst[:0], 32)
+		dst = value.AppendHex(dst)
+	})
+
+	if allocs != 0 {
+		t.Error("append should not allocate")
+	}
+}
+
+func TestValueWipe(t *testing.T) {
+	buff := []byte{1, 2, 3}
+	value := v.New(buff)
+	raw := value.Bytes()
+	value.Wipe()
+
+	if !b.Equal(raw, []byte{0, 0, 0}) || value.Int().Sign() != 0 {
+		t.Error("value should be wiped")
+	}
+
+	if !b.Equal(buff, []byte{1, 2, 3}) {
+		t.Error("source bytes should not be wiped")
+	}
+}
+
+func BenchmarkValueAppendPadded(bm *testing.B) {
+	value := v.New(benchBytes)
+	dst := make([]byte, 0, 512)
+	bm.ReportAllocs()
+
+	for i := 0; i < bm.N; i++ {
+		dst = value.AppendPadded(dst[:0], 256)
+	}
+}
+
+func BenchmarkValueNewFromBytes(bm *testing.B) {
+	bm.ReportAllocs()
+
+	for i := 0; i < bm.N; i++ {
+		v.New(benchBytes)
+	}
+}
+
+func BenchmarkValueNewFromInt(bm *testing.B) {
+	bm.ReportAllocs()
+
+	for i := 0; i < bm.N; i++ {
+		v.New(benchInt)
+	}
+}
+
+func BenchmarkValueNewFromHex(bm *testing.B) {
+	bm.ReportAllocs()
+
+	for i := 0; i < bm.N; i++ {
+		v.New(benchHex)
+	}
+}
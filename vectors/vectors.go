@@ -0,0 +1,268 @@
+// Package vectors canonical test suite for built-in engine profiles
+//
+// Exports behavior of every built-in engine profile for fixed inputs in
+// language-agnostic form (JSON), so clients ported to other languages
+// can be validated byte-for-byte against this implementation.
+package vectors
+
+import (
+	hash "crypto"
+	"encoding/json"
+	"errors"
+
+	c "github.com/nsheremet/esrp/crypto"
+	e "github.com/nsheremet/esrp/engine"
+	g "github.com/nsheremet/esrp/group"
+	v "github.com/nsheremet/esrp/value"
+)
+
+// Profile struct: built-in engine profile
+//
+// Provides:
+// Name   - profile identifier
+// Engine - engine name
+// Hash   - one-way hash function name
+// KDF    - password-based key derivation function name
+// MAC    - keyed hash transform function name
+type Profile struct {
+	Name   string `json:"name"`
+	Engine string `json:"engine"`
+	Hash   string `json:"hash"`
+	KDF    string `json:"kdf"`
+	MAC    string `json:"mac"`
+
+	engine func(grp g.Group) calculator
+}
+
+// Inputs struct: fixed inputs, all values are hex strings
+type Inputs struct {
+	N        string `json:"N"`
+	G        string `json:"g"`
+	Username string `json:"I"`
+	Password string `json:"p"`
+	Salt     string `json:"s"`
+	A        string `json:"a"`
+	B        string `json:"b"`
+}
+
+// Outputs struct: computed values, all values are hex strings
+type Outputs struct {
+	K  string `json:"k"`
+	X  string `json:"x"`
+	V  string `json:"v"`
+	AA string `json:"A"`
+	BB string `json:"B"`
+	U  string `json:"u"`
+	S  string `json:"S"`
+	KK string `json:"K"`
+	M  string `json:"M,omitempty"`
+	M2 string `json:"M2,omitempty"`
+}
+
+// Case struct: single suite entry
+type Case struct {
+	Profile Profile `json:"profile"`
+	Inputs  Inputs  `json:"inputs"`
+	Outputs Outputs `json:"outputs"`
+}
+
+// Suite struct: complete exported suite
+type Suite struct {
+	Version int    `json:"version"`
+	Cases   []Case `json:"cases"`
+}
+
+// Profiles {[]Profile} built-in engine profiles
+var Profiles = []Profile{
+	{Name: "standard-sha1", Engine: "standard", Hash: "SHA1", KDF: "PBKDF2", MAC: "HMAC", engine: newStandard(c.NewStandard(hash.SHA1))},
+	{Name: "standard-sha256", Engine: "standard", Hash: "SHA256", KDF: "PBKDF2", MAC: "HMAC", engine: newStandard(c.NewStandard(hash.SHA256))},
+	{Name: "standard-sha384", Engine: "standard", Hash: "SHA384", KDF: "PBKDF2", MAC: "HMAC", engine: newStandard(c.NewStandard(hash.SHA384))},
+	{Name: "standard-sha512", Engine: "standard", Hash: "SHA512", KDF: "PBKDF2", MAC: "HMAC", engine: newStandard(c.NewStandard(hash.SHA512))},
+	{Name: "standard-sha224", Engine: "standard", Hash: "SHA224", KDF: "PBKDF2", MAC: "HMAC", engine: newStandard(c.NewStandard(hash.SHA224))},
+	{Name: "standard-sha512-256", Engine: "standard", Hash: "SHA512_256", KDF: "PBKDF2", MAC: "HMAC", engine: newStandard(c.NewStandard(hash.SHA512_256))},
+	{Name: "standard-sha3-256", Engine: "standard", Hash: "SHA3_256", KDF: "PBKDF2", MAC: "HMAC", engine: newStandard(c.NewStandard(hash.SHA3_256))},
+	{Name: "standard-sha3-512", Engine: "standard", Hash: "SHA3_512", KDF: "PBKDF2", MAC: "HMAC", engine: newStandard(c.NewStandard(hash.SHA3_512))},
+	{Name: "standard-blake2b-256", Engine: "standard", Hash: "BLAKE2b_256", KDF: "PBKDF2", MAC: "BLAKE2b", engine: newStandard(c.NewStandardWithParams(hash.BLAKE2b_256, c.StandardParams{Mac: c.Blake2Keyed}))},
+	{Name: "standard-legacy-sha1", Engine: "standard", Hash: "SHA1", KDF: "H(s | p)", MAC: "H(m | k)", engine: newStandard(c.NewStandardWithParams(hash.SHA1, c.StandardParams{LegacyKdf: true, Mac: c.LegacyConcat}))},
+	{Name: "standard-legacy-sha256", Engine: "standard", Hash: "SHA256", KDF: "H(s | p)", MAC: "H(m | k)", engine: newStandard(c.NewStandardWithParams(hash.SHA256, c.StandardParams{LegacyKdf: true, Mac: c.LegacyConcat}))},
+	{Name: "standard-sha256-kmac256", Engine: "standard", Hash: "SHA256", KDF: "PBKDF2", MAC: "KMAC256", engine: newStandard(c.NewStandardWithParams(hash.SHA256, c.StandardParams{Mac: c.Kmac256}))},
+	{Name: "srp-rb", Engine: "srp-rb", Hash: "SHA1", KDF: "H(s | H(I | \":\" | p))", MAC: "H(A | B | K)", engine: newSrpRb},
+	{Name: "gnutls", Engine: "gnutls", Hash: "SHA1", KDF: "H(s | H(I | \":\" | p))", MAC: "none", engine: newGnuTLS},
+	{Name: "rfc5054", Engine: "rfc5054", Hash: "SHA1", KDF: "H(s | H(I | \":\" | p))", MAC: "H(H(N) xor H(g) | H(I) | s | A | B | K)", engine: newRFC5054},
+}
+
+// calculator interface: engine methods used for suite computation
+type calculator interface {
+	CalcX(password string, salt v.Value, username string) v.Value
+	K() v.Value
+	CalcV(x v.Value) v.Value
+	CalcA(a v.Value) v.Value
+	CalcB(b, val v.Value) v.Value
+	CalcU(aa, bb v.Value) v.Value
+	CalcServerS(aa, b, val, u v.Value) v.Value
+	CalcK(ss v.Value) v.Value
+}
+
+// prover interface: optional validation messages, absent in TLS-SRP engines
+type prover interface {
+	CalcM(kk, aa, bb, ss, salt v.Value, username string) v.Value
+	CalcM2(kk, aa, mm, ss v.Value) v.Value
+}
+
+// standard struct: adapts engine.Standard to calculator
+type standard struct {
+	e.Standard
+}
+
+// CalcX function: engine.Standard takes salt as hex and ignores username
+func (s standard) CalcX(password string, salt v.Value, username string) v.Value {
+	return s.Standard.CalcX(password, salt.Hex())
+}
+
+// newStandard function: engine.Standard constructor for given crypto
+func newStandard(crypto c.Crypto) func(grp g.Group) calculator {
+	return func(grp g.Group) calculator {
+		return standard{e.Standard{Engine: e.New(crypto, grp)}}
+	}
+}
+
+// newGnuTLS function: engine.GnuTLS constructor
+func newGnuTLS(grp g.Group) calculator {
+	return e.NewGnuTLS(grp)
+}
+
+// newRFC5054 function: engine.RFC5054 constructor
+func newRFC5054(grp g.Group) calculator {
+	return e.NewRFC5054(grp)
+}
+
+// newSrpRb function: engine.SrpRb constructor
+func newSrpRb(grp g.Group) calculator {
+	return e.NewSrpRb(grp)
+}
+
+// DefaultInputs {Inputs} fixed inputs, taken from https://tools.ietf.org/html/rfc5054#appendix-B
+var DefaultInputs = Inputs{
+	N: "eeaf0ab9adb38dd69c33f80afa8fc5e86072618775ff3c0b9ea2314c9c256576" +
+		"d674df7496ea81d3383b4813d692c6e0e0d5d8e250b98be48e495c1d6089dad1" +
+		"5dc7d7b46154d6b6ce8ef4ad69b15d4982559b297bcf1885c529f566660e57ec" +
+		"68edbc3c05726cc02fd4cbf4976eaa9afd5138fe8376435b9fc61d2fc0eb06e3",
+	G:        "02",
+	Username: "alice",
+	Password: "password123",
+	Salt:     "beb25379d1a8581eb5a727673a2441ee",
+	A:        "60975527035cf2ad1989806f0407210bc81edc04e2762a56afd529ddda2d4393",
+	B:        "e487cb59d31ac550471e81f00f6928e01dda08e974a004f49e61f5d105284d20",
+}
+
+// ErrInvalidInputs {error} inputs are not hex strings
+var ErrInvalidInputs = errors.New("vectors: inputs should be non-empty hex strings")
+
+// Generate function: run every built-in profile against inputs
+//
+// Params:
+// - inputs {Inputs} fixed inputs
+//
+// Response:
+// - {Suite}
+// - {error} ErrInvalidInputs or group validation error
+func Generate(inputs Inputs) (Suite, error) {
+	grp, err := group(inputs)
+
+	if err != nil {
+		return Suite{}, err
+	}
+
+	suite := Suite{Version: 1}
+
+	for _, profile := range Profiles {
+		suite.Cases = append(suite.Cases, Case{
+			Profile: profile,
+			Inputs:  inputs,
+			Outputs: calc(profile, grp, inputs),
+		})
+	}
+
+	return suite, nil
+}
+
+// JSON function: suite in JSON representation
+//
+// Params:
+// - suite {Suite}
+//
+// Response:
+// - {[]byte}
+// - {error}
+func JSON(suite Suite) ([]byte, error) {
+	return json.MarshalIndent(suite, "", "  ")
+}
+
+// group function: validated group of inputs
+//
+// Generator may be of arbitrary size, leading zeros of N and g are
+// dropped and prime length is taken from N.
+//
+// Params:
+// - inputs {Inputs}
+//
+// Response:
+// - {g.Group}
+// - {error}
+func group(inputs Inputs) (g.Group, error) {
+	for _, str := range []string{inputs.N, inputs.G, inputs.Salt, inputs.A, inputs.B} {
+		if !v.IsHex(str) {
+			return g.Group{}, ErrInvalidInputs
+		}
+	}
+
+	nn := v.New(inputs.N).Int()
+	grp := g.NewWithGenerator(nn.BitLen(), v.New(v.New(inputs.G).Int()).Hex(), v.New(nn).Hex())
+
+	if err := grp.Validate(); err != nil {
+		return g.Group{}, err
+	}
+
+	return grp, nil
+}
+
+// calc function: compute all values for single profile
+//
+// Params:
+// - profile {Profile}
+// - grp     {g.Group} validated group
+// - inputs  {Inputs}
+//
+// Response:
+// - {Outputs}
+func calc(profile Profile, grp g.Group, inputs Inputs) Outputs {
+	engine := profile.engine(grp)
+	salt := v.New(inputs.Salt)
+
+	x := engine.CalcX(inputs.Password, salt, inputs.Username)
+	val := engine.CalcV(x)
+	aa := engine.CalcA(v.New(inputs.A))
+	bb := engine.CalcB(v.New(inputs.B), val)
+	u := engine.CalcU(aa, bb)
+	ss := engine.CalcServerS(aa, v.New(inputs.B), val, u)
+	kk := engine.CalcK(ss)
+
+	outputs := Outputs{
+		K:  engine.K().Hex(),
+		X:  x.Hex(),
+		V:  val.Hex(),
+		AA: aa.Hex(),
+		BB: bb.Hex(),
+		U:  u.Hex(),
+		S:  ss.Hex(),
+		KK: kk.Hex(),
+	}
+
+	if p, ok := engine.(prover); ok {
+		mm := p.CalcM(kk, aa, bb, ss, salt, inputs.Username)
+		outputs.M = mm.Hex()
+		outputs.M2 = p.CalcM2(kk, aa, mm, ss).Hex()
+	}
+
+	return outputs
+}
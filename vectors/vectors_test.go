@@ -0,0 +1,79 @@
+package vectors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	g "github.com/nsheremet/esrp/group"
+	"github.com/nsheremet/esrp/vectors"
+)
+
+func TestVectorsGenerate(t *testing.T) {
+	suite, err := vectors.Generate(vectors.DefaultInputs)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(suite.Cases) != len(vectors.Profiles) {
+		t.Error("every profile should be exported")
+	}
+
+	if suite.Cases[0].Outputs.K != "7556aa045aef2cdd07abaf0f665c3e818913186f" {
+		t.Error("k should be equal to RFC5054 vector")
+	}
+}
+
+func TestVectorsGenerateWithPaddedInputs(t *testing.T) {
+	inputs := vectors.DefaultInputs
+	inputs.N = "00" + inputs.N
+	inputs.G = "0002"
+
+	suite, err := vectors.Generate(inputs)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if suite.Cases[0].Outputs.K != "7556aa045aef2cdd07abaf0f665c3e818913186f" {
+		t.Error("padded inputs should give the same k")
+	}
+}
+
+func TestVectorsGenerateRejectsInvalidInputs(t *testing.T) {
+	large := vectors.DefaultInputs
+	large.G = "01" + large.N
+
+	if _, err := vectors.Generate(large); err != g.ErrInvalidGenerator {
+		t.Errorf("generator out of group should be rejected, got %v", err)
+	}
+
+	malformed := vectors.DefaultInputs
+	malformed.Salt = "xyz"
+
+	if _, err := vectors.Generate(malformed); err != vectors.ErrInvalidInputs {
+		t.Errorf("malformed inputs should be rejected, got %v", err)
+	}
+}
+
+func TestVectorsJSON(t *testing.T) {
+	generated, _ := vectors.Generate(vectors.DefaultInputs)
+	regenerated, _ := vectors.Generate(vectors.DefaultInputs)
+	first, _ := vectors.JSON(generated)
+	second, _ := vectors.JSON(regenerated)
+
+	if !bytes.Equal(first, second) {
+		t.Error("suite should be deterministic")
+	}
+
+	var suite vectors.Suite
+
+	if err := json.Unmarshal(first, &suite); err != nil {
+		t.Error(err)
+	}
+
+	if suite.Cases[1].Profile.Name != "standard-sha256" {
+		t.Error("profile should be equal")
+	}
+}